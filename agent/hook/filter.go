@@ -0,0 +1,17 @@
+package hook
+
+import (
+	"github.com/hypershadow-io/contract/agent/model"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/hook/expr"
+)
+
+// Fields lists the fields of agent models available in declarative hook filter expressions.
+var Fields = expr.Fields[model.Model]{
+	"id":     expr.Field(model.Model.GetID),
+	"title":  expr.Field(model.Model.GetTitle),
+	"status": expr.Field(model.Model.GetStatus),
+}
+
+// CompileFilter compiles a declarative filter expression into a hook filter for agent models.
+func CompileFilter(e expr.Expr) (hook.Filter[model.Model], error) { return expr.Compile(e, Fields) }
@@ -0,0 +1,21 @@
+package model
+
+import "github.com/hypershadow-io/contract/meta"
+
+// Snapshot defines the serializable state of the agent.
+type Snapshot struct {
+	ID     int64     `json:"id"`
+	Title  string    `json:"title"`
+	Status Status    `json:"status"`
+	Meta   meta.Meta `json:"meta,omitempty"`
+}
+
+// NewSnapshot copies the agent into a Snapshot.
+func NewSnapshot(m Model) Snapshot {
+	return Snapshot{
+		ID:     m.GetID(),
+		Title:  m.GetTitle(),
+		Status: m.GetStatus(),
+		Meta:   m.GetMeta(),
+	}
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hypershadow-io/contract/meta"
+)
+
+// Snapshot defines the serializable state of the agent token.
+type Snapshot struct {
+	ID        int64     `json:"id"`
+	LookupKey string    `json:"lookupKey"`
+	ExpiredAt time.Time `json:"expiredAt"`
+	Meta      meta.Meta `json:"meta,omitempty"`
+}
+
+// NewSnapshot copies the agent token into a Snapshot.
+func NewSnapshot(m Model) Snapshot {
+	return Snapshot{
+		ID:        m.GetID(),
+		LookupKey: m.GetLookupKey(),
+		ExpiredAt: m.GetExpiredAt(),
+		Meta:      m.GetMeta(),
+	}
+}
@@ -0,0 +1,17 @@
+package hook
+
+import (
+	"github.com/hypershadow-io/contract/apitoken/model"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/hook/expr"
+)
+
+// Fields lists the fields of API tokens available in declarative hook filter expressions.
+var Fields = expr.Fields[model.Model]{
+	"id":    expr.Field(model.Model.GetID),
+	"title": expr.Field(model.Model.GetTitle),
+	"valid": expr.Field(model.Model.IsValid),
+}
+
+// CompileFilter compiles a declarative filter expression into a hook filter for API tokens.
+func CompileFilter(e expr.Expr) (hook.Filter[model.Model], error) { return expr.Compile(e, Fields) }
@@ -4,7 +4,8 @@ go 1.24.0
 
 require (
 	github.com/hypershadow-io/contract/apitoken/model v1.0.0
-	github.com/hypershadow-io/contract/hook v1.0.0
+	github.com/hypershadow-io/contract/hook v1.1.0
+	github.com/hypershadow-io/contract/hook/expr v1.0.0
 	github.com/hypershadow-io/contract/qb v1.2.0
 )
 
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hypershadow-io/contract/meta"
+)
+
+// Snapshot defines the serializable state of the API token.
+type Snapshot struct {
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	ExpiredAt time.Time `json:"expiredAt"`
+	Meta      meta.Meta `json:"meta,omitempty"`
+}
+
+// NewSnapshot copies the API token into a Snapshot.
+func NewSnapshot(m Model) Snapshot {
+	return Snapshot{
+		ID:        m.GetID(),
+		Title:     m.GetTitle(),
+		ExpiredAt: m.GetExpiredAt(),
+		Meta:      m.GetMeta(),
+	}
+}
@@ -17,6 +17,13 @@ type (
 
 		// Delete removes a value from the cache by key.
 		Delete(c context.Context, key any) error
+
+		// InvalidateTag removes all values tagged with any of the given tags (see WithSetTags).
+		InvalidateTag(c context.Context, tags ...string) error
+
+		// DeletePrefix removes all values whose string keys start with the given prefix.
+		// Keys of other types are not affected.
+		DeletePrefix(c context.Context, prefix string) error
 	}
 
 	// SetOption defines a function used to configure cache set behavior (e.g., TTL).
@@ -26,6 +33,9 @@ type (
 	setOption interface {
 		// SetTTL sets the time-to-live (TTL) for the cached value.
 		SetTTL(time.Duration)
+
+		// SetTags attaches invalidation tags to the cached value.
+		SetTags(...string)
 	}
 )
 
@@ -33,3 +43,13 @@ type (
 func WithSetTTL(v time.Duration) SetOption {
 	return func(opt setOption) { opt.SetTTL(v) }
 }
+
+// WithSetTags returns a SetOption that attaches invalidation tags to the cached value,
+// so it can be removed together with other values of the same tag (see Instance.InvalidateTag).
+//
+// Example:
+//
+//	cache.WithSetTags("integration:" + strconv.FormatInt(integrationID, 10))
+func WithSetTags(v ...string) SetOption {
+	return func(opt setOption) { opt.SetTags(v...) }
+}
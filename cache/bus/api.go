@@ -0,0 +1,177 @@
+package bus
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/hypershadow-io/contract/cache"
+)
+
+type (
+	// Bus delivers cache invalidation messages to every node of the cluster.
+	// Delivery is best-effort: implementations that may lose messages must deliver a Reset message
+	// once delivery is restored, so subscribers can drop everything they may have missed.
+	Bus interface {
+		// Publish sends the message to the subscribers of all nodes, including the current one.
+		Publish(c context.Context, message Message) error
+
+		// Subscribe registers the handler for all published messages.
+		// Returns a function removing the subscription.
+		Subscribe(handler Handler) (unsubscribe_ func())
+	}
+
+	// Handler processes an invalidation message.
+	Handler func(c context.Context, message Message)
+
+	// Message describes values to remove from the cache instances of the same name on every node.
+	Message struct {
+		Origin   string   `json:"origin"`             // ID of the publishing instance, which skips its own messages
+		Instance string   `json:"instance,omitempty"` // name of the cache instance; empty addresses all instances
+		Keys     []string `json:"keys,omitempty"`     // keys to delete
+		Tags     []string `json:"tags,omitempty"`     // tags to invalidate
+		Prefixes []string `json:"prefixes,omitempty"` // key prefixes to delete
+		Reset    bool     `json:"reset,omitempty"`    // all values must be dropped (messages may have been lost)
+	}
+
+	// Instance is a cache instance whose invalidations are broadcast to the instances of the same name
+	// on every node, and which applies invalidations received from them.
+	// Only string keys are propagated by Delete; use string keys for cluster-wide caches.
+	Instance struct {
+		name        string
+		origin      string
+		local       cache.Instance
+		bus         Bus
+		options     attachOptions
+		unsubscribe func()
+	}
+
+	// AttachOption defines a function used to configure an attached instance.
+	AttachOption func(attachOption)
+
+	// attachOption defines internal configuration methods for attach options.
+	attachOption interface {
+		// SetMaxStale sets the upper bound of value TTLs.
+		SetMaxStale(time.Duration)
+	}
+
+	// attachOptions holds settings of an attached instance.
+	attachOptions struct {
+		maxStale time.Duration
+	}
+
+	// setOptions captures the options of a Set call to cap its TTL.
+	setOptions struct {
+		ttl  time.Duration
+		tags []string
+	}
+)
+
+// Attach wraps the local cache instance, so that its invalidations are published to the bus
+// and invalidations published by the instances of the same name on other nodes are applied to it.
+// The name must be the same on every node and unique among the cache instances sharing the bus.
+// Call Close to stop receiving invalidations.
+//
+// Example:
+//
+//	instance = bus.Attach("agent.byid", instance, invalidationBus, bus.WithAttachMaxStale(time.Minute))
+func Attach(name string, instance cache.Instance, b Bus, opts ...AttachOption) *Instance {
+	result := &Instance{
+		name:   name,
+		origin: rand.Text(),
+		local:  instance,
+		bus:    b,
+	}
+	for _, opt := range opts {
+		opt(&result.options)
+	}
+	result.unsubscribe = b.Subscribe(result.apply)
+	return result
+}
+
+// WithAttachMaxStale caps the TTL of every value set through the instance, which bounds how long a node
+// may serve a stale value if an invalidation message is lost.
+func WithAttachMaxStale(v time.Duration) AttachOption {
+	return func(opt attachOption) { opt.SetMaxStale(v) }
+}
+
+func (a *Instance) Get(c context.Context, errBuilder func() error, key any) (res_ any, found_ bool, err_ error) {
+	return a.local.Get(c, errBuilder, key)
+}
+
+func (a *Instance) Set(c context.Context, key any, value any, opts ...cache.SetOption) error {
+	if a.options.maxStale <= 0 {
+		return a.local.Set(c, key, value, opts...)
+	}
+	o := &setOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	ttl := a.options.maxStale
+	if o.ttl > 0 && o.ttl < ttl {
+		ttl = o.ttl
+	}
+	return a.local.Set(c, key, value, cache.WithSetTTL(ttl), cache.WithSetTags(o.tags...))
+}
+
+// Delete removes the value locally and, if the key is a string, on every node.
+func (a *Instance) Delete(c context.Context, key any) error {
+	if err := a.local.Delete(c, key); err != nil {
+		return err
+	}
+	if s, ok := key.(string); ok {
+		return a.publish(c, Message{Keys: []string{s}})
+	}
+	return nil
+}
+
+// InvalidateTag removes the tagged values locally and on every node.
+func (a *Instance) InvalidateTag(c context.Context, tags ...string) error {
+	if err := a.local.InvalidateTag(c, tags...); err != nil {
+		return err
+	}
+	return a.publish(c, Message{Tags: tags})
+}
+
+// DeletePrefix removes the values with the key prefix locally and on every node.
+func (a *Instance) DeletePrefix(c context.Context, prefix string) error {
+	if err := a.local.DeletePrefix(c, prefix); err != nil {
+		return err
+	}
+	return a.publish(c, Message{Prefixes: []string{prefix}})
+}
+
+// Close stops receiving invalidations from other nodes.
+func (a *Instance) Close() { a.unsubscribe() }
+
+// publish sends the message on behalf of the instance.
+func (a *Instance) publish(c context.Context, message Message) error {
+	message.Origin, message.Instance = a.origin, a.name
+	return a.bus.Publish(c, message)
+}
+
+// apply removes the values described by a message of another node.
+// A Reset message drops all values with string keys.
+func (a *Instance) apply(c context.Context, message Message) {
+	if message.Origin == a.origin || (message.Instance != "" && message.Instance != a.name) {
+		return
+	}
+	if message.Reset {
+		_ = a.local.DeletePrefix(c, "")
+		return
+	}
+	for _, key := range message.Keys {
+		_ = a.local.Delete(c, key)
+	}
+	if len(message.Tags) > 0 {
+		_ = a.local.InvalidateTag(c, message.Tags...)
+	}
+	for _, prefix := range message.Prefixes {
+		_ = a.local.DeletePrefix(c, prefix)
+	}
+}
+
+func (a *attachOptions) SetMaxStale(v time.Duration) { a.maxStale = v }
+
+func (a *setOptions) SetTTL(v time.Duration) { a.ttl = v }
+func (a *setOptions) SetTags(v ...string)    { a.tags = v }
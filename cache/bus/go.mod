@@ -0,0 +1,5 @@
+module github.com/hypershadow-io/contract/cache/bus
+
+go 1.24.0
+
+require github.com/hypershadow-io/contract/cache v1.2.0
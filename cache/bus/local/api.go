@@ -0,0 +1,48 @@
+package local
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hypershadow-io/contract/cache/bus"
+)
+
+// New returns an in-process bus delivering messages synchronously to the subscribers of the current process.
+// Suitable for single-node deployments and tests; it never loses messages.
+func New() bus.Bus {
+	return &Bus{handlers: make(map[int]bus.Handler)}
+}
+
+// Bus is an in-process invalidation bus.
+type Bus struct {
+	locker   sync.RWMutex
+	handlers map[int]bus.Handler
+	nextID   int
+}
+
+// Publish calls all subscribed handlers before returning.
+func (a *Bus) Publish(c context.Context, message bus.Message) error {
+	a.locker.RLock()
+	handlers := make([]bus.Handler, 0, len(a.handlers))
+	for _, handler := range a.handlers {
+		handlers = append(handlers, handler)
+	}
+	a.locker.RUnlock()
+	for _, handler := range handlers {
+		handler(c, message)
+	}
+	return nil
+}
+
+func (a *Bus) Subscribe(handler bus.Handler) (unsubscribe_ func()) {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	id := a.nextID
+	a.nextID++
+	a.handlers[id] = handler
+	return func() {
+		a.locker.Lock()
+		defer a.locker.Unlock()
+		delete(a.handlers, id)
+	}
+}
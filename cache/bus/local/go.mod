@@ -0,0 +1,7 @@
+module github.com/hypershadow-io/contract/cache/bus/local
+
+go 1.24.0
+
+require github.com/hypershadow-io/contract/cache/bus v1.0.0
+
+require github.com/hypershadow-io/contract/cache v1.2.0 // indirect
@@ -0,0 +1,156 @@
+package pg
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/hypershadow-io/contract/cache/bus"
+	"github.com/hypershadow-io/contract/cache/bus/local"
+	"github.com/hypershadow-io/contract/db"
+	"github.com/hypershadow-io/contract/eb"
+	"github.com/hypershadow-io/contract/json"
+	"github.com/hypershadow-io/contract/runner"
+)
+
+const (
+	// DefaultChannel is the default notification channel.
+	DefaultChannel = "cache_invalidation"
+
+	// DefaultReconnectDelay is the default delay before listening again after the connection is lost.
+	DefaultReconnectDelay = time.Second
+
+	// MaxPayload is the maximum size of a notification payload accepted by PostgreSQL.
+	// Larger messages are replaced with a Reset message of the same instance.
+	MaxPayload = 8000
+)
+
+// New returns a bus delivering messages between nodes through PostgreSQL LISTEN/NOTIFY.
+// Messages are received only while the command returned by Command is running.
+// After listening starts or resumes, subscribers receive a Reset message,
+// so values whose invalidations were missed are dropped.
+//
+// Example:
+//
+//	invalidationBus := pg.New(platformDB.(db.Listener))
+//	runnerClient.Add(invalidationBus.Command())
+func New(listener db.Listener, opts ...Option) *Bus {
+	result := &Bus{
+		listener:    listener,
+		subscribers: local.New(),
+		options: options{
+			channel:        DefaultChannel,
+			reconnectDelay: DefaultReconnectDelay,
+			logger:         slog.WarnContext,
+		},
+	}
+	for _, opt := range opts {
+		opt(&result.options)
+	}
+	return result
+}
+
+// WithChannel sets the notification channel (DefaultChannel by default).
+func WithChannel(v string) Option {
+	return func(o option) { o.SetChannel(v) }
+}
+
+// WithReconnectDelay sets the delay before listening again after the connection is lost
+// (DefaultReconnectDelay by default). Together with the TTL cap of attached instances
+// it bounds how long a node may serve stale values.
+func WithReconnectDelay(v time.Duration) Option {
+	return func(o option) { o.SetReconnectDelay(v) }
+}
+
+// WithLogger sets the logger for lost connections and malformed messages (slog.WarnContext by default).
+func WithLogger(v eb.LogFunc) Option {
+	return func(o option) { o.SetLogger(v) }
+}
+
+type (
+	// Bus is an invalidation bus backed by PostgreSQL LISTEN/NOTIFY.
+	Bus struct {
+		listener    db.Listener
+		subscribers bus.Bus
+		options     options
+	}
+
+	// Option defines a functional option for the bus.
+	Option func(option)
+
+	// option is an internal interface used to apply configuration.
+	option interface {
+		// SetChannel sets the notification channel.
+		SetChannel(string)
+
+		// SetReconnectDelay sets the delay before listening again.
+		SetReconnectDelay(time.Duration)
+
+		// SetLogger sets the logger.
+		SetLogger(eb.LogFunc)
+	}
+
+	// options holds bus settings.
+	options struct {
+		channel        string
+		reconnectDelay time.Duration
+		logger         eb.LogFunc
+	}
+)
+
+// Publish sends the message to all nodes. If the context holds a transaction,
+// the message is delivered only after it is committed.
+func (a *Bus) Publish(c context.Context, message bus.Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if len(data) > MaxPayload {
+		data, err = json.Marshal(bus.Message{Origin: message.Origin, Instance: message.Instance, Reset: true})
+		if err != nil {
+			return err
+		}
+	}
+	return a.listener.Notify(c, a.options.channel, string(data))
+}
+
+func (a *Bus) Subscribe(handler bus.Handler) (unsubscribe_ func()) {
+	return a.subscribers.Subscribe(handler)
+}
+
+// Command returns the command listening for messages of other nodes.
+// It should be registered once in the runner.
+func (a *Bus) Command() runner.Command {
+	return runner.MakeCommand(a.listen)
+}
+
+// listen receives messages until the context is canceled, listening again whenever the connection is lost.
+func (a *Bus) listen(c context.Context) error {
+	ready := db.WithListenOnReady(func() { _ = a.subscribers.Publish(c, bus.Message{Reset: true}) })
+	for {
+		err := a.listener.Listen(c, a.options.channel, func(payload string) { a.receive(c, payload) }, ready)
+		if c.Err() != nil {
+			return nil
+		}
+		a.options.logger(c, "cache invalidation listener disconnected", "channel", a.options.channel, "error", err)
+		select {
+		case <-c.Done():
+			return nil
+		case <-time.After(a.options.reconnectDelay):
+		}
+	}
+}
+
+// receive delivers the message of the payload to the subscribers.
+func (a *Bus) receive(c context.Context, payload string) {
+	var message bus.Message
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		a.options.logger(c, "malformed cache invalidation message", "channel", a.options.channel, "error", err)
+		return
+	}
+	_ = a.subscribers.Publish(c, message)
+}
+
+func (a *options) SetChannel(v string)               { a.channel = v }
+func (a *options) SetReconnectDelay(v time.Duration) { a.reconnectDelay = v }
+func (a *options) SetLogger(v eb.LogFunc)            { a.logger = v }
@@ -0,0 +1,20 @@
+module github.com/hypershadow-io/contract/cache/bus/pg
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/cache/bus v1.0.0
+	github.com/hypershadow-io/contract/cache/bus/local v1.0.0
+	github.com/hypershadow-io/contract/db v1.3.0
+	github.com/hypershadow-io/contract/eb v1.3.0
+	github.com/hypershadow-io/contract/json v1.1.0
+	github.com/hypershadow-io/contract/runner v1.0.0
+)
+
+require (
+	github.com/hypershadow-io/contract/cache v1.2.0 // indirect
+	github.com/hypershadow-io/contract/codec v1.0.0 // indirect
+	github.com/hypershadow-io/contract/fielderror v1.1.0 // indirect
+	github.com/hypershadow-io/contract/meta v1.0.0 // indirect
+	github.com/hypershadow-io/contract/utiliter v1.0.0 // indirect
+)
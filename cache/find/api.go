@@ -0,0 +1,228 @@
+package find
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/hypershadow-io/contract/cache"
+	"github.com/hypershadow-io/contract/hook"
+	orgctx "github.com/hypershadow-io/contract/organization/ctx"
+)
+
+// ByID decorates a find-by-ID client (e.g. agent/find/byid.Client, operation/find/byid.Client,
+// integration/find/byid.Client, apitoken/find/byid.Client) with the cache instance.
+// Values are keyed by the name, the organization of the context and the ID, and are invalidated
+// in every organization when the model event registry reports a completed creation, update or deletion of the model
+// (the After phase).
+// The result implements the decorated client interface.
+//
+// Cached models are shared between callers and must not be modified.
+//
+// Example:
+//
+//	var agents agentbyid.Client = find.ByID("agent.byid", agentFinder, instance, orgClient, agentHook.ModelEvent(pluginID))
+func ByID[M interface{ GetID() int64 }](
+	name string,
+	client interface {
+		FindByID(c context.Context, id int64) (res_ M, found_ bool, err_ error)
+	},
+	instance cache.Instance,
+	orgClient orgctx.Client,
+	events hook.Event[M],
+	opts ...Option,
+) *ByIDClient[M] {
+	return &ByIDClient[M]{
+		Finder: New(name, client.FindByID, M.GetID, instance, orgClient, events, opts...),
+	}
+}
+
+// ByLookupKey decorates a find-by-lookup-key client (e.g. agenttoken/find/bylookupkey.Client) with the cache instance.
+// Lookup keys are globally unique and are resolved before the organization is known,
+// so values are keyed by the name and the lookup key only (see WithoutOrganization).
+// The result implements the decorated client interface.
+func ByLookupKey[M interface{ GetLookupKey() string }](
+	name string,
+	client interface {
+		FindByLookupKey(c context.Context, key string) (res_ M, found_ bool, err_ error)
+	},
+	instance cache.Instance,
+	orgClient orgctx.Client,
+	events hook.Event[M],
+	opts ...Option,
+) *ByLookupKeyClient[M] {
+	opts = append([]Option{WithoutOrganization()}, opts...)
+	return &ByLookupKeyClient[M]{
+		Finder: New(name, client.FindByLookupKey, M.GetLookupKey, instance, orgClient, events, opts...),
+	}
+}
+
+// New decorates the find function with the cache instance.
+// Concurrent lookups of the same key share a single call (see cache.GetOrLoad).
+// The invalidation handler is registered in the event registry; remove it with Close.
+//
+// Values are cached as cache.LoadEntry[M], and M is often an interface (e.g. model.Model) that codecs
+// cannot decode, so the instance must keep values in process: a local instance, optionally attached
+// to a bus (see cache/bus). Remote and two-tier instances (cache/remote, cache/tiered) are not supported.
+func New[K Key, M any](
+	name string,
+	find func(c context.Context, key K) (res_ M, found_ bool, err_ error),
+	keyOf func(M) K,
+	instance cache.Instance,
+	orgClient orgctx.Client,
+	events hook.Event[M],
+	opts ...Option,
+) *Finder[K, M] {
+	result := &Finder[K, M]{
+		name:      name,
+		find:      find,
+		instance:  instance,
+		orgClient: orgClient,
+		options:   options{scoped: true},
+	}
+	for _, opt := range opts {
+		opt(&result.options)
+	}
+	result.registration = events.Add(
+		// a load running before the change is applied must not cache the previous model for good,
+		// so models are invalidated once the change is done (loads racing with it are covered by the generation)
+		hook.AndFilters(
+			hook.MatchKind[M](hook.KindAfter),
+			hook.MatchAnyKinds[M](hook.KindCreate, hook.KindUpdate, hook.KindDelete),
+		),
+		func(c context.Context, _ hook.Kinds, value M) error {
+			return result.Invalidate(c, keyOf(value))
+		},
+	)
+	return result
+}
+
+// WithTTL sets the TTL of found models (the instance default if zero).
+func WithTTL(v time.Duration) Option {
+	return func(o option) { o.SetTTL(v) }
+}
+
+// WithNegativeTTL enables caching of not-found results for the given duration.
+func WithNegativeTTL(v time.Duration) Option {
+	return func(o option) { o.SetNegativeTTL(v) }
+}
+
+// WithoutOrganization keys values without the organization of the context,
+// for keys that are globally unique and looked up outside of an organization.
+func WithoutOrganization() Option {
+	return func(o option) { o.SetScoped(false) }
+}
+
+type (
+	// Key is the type of lookup keys.
+	Key interface{ int64 | string }
+
+	// Finder is a cached find function.
+	Finder[K Key, M any] struct {
+		name         string
+		find         func(c context.Context, key K) (res_ M, found_ bool, err_ error)
+		instance     cache.Instance
+		orgClient    orgctx.Client
+		options      options
+		registration hook.Registration[hook.EventFunc[M], M]
+		generation   atomic.Uint64 // changed by every invalidation, see cache.WithLoadVersion
+	}
+
+	// ByIDClient is a cached find-by-ID client.
+	ByIDClient[M any] struct {
+		*Finder[int64, M]
+	}
+
+	// ByLookupKeyClient is a cached find-by-lookup-key client.
+	ByLookupKeyClient[M any] struct {
+		*Finder[string, M]
+	}
+
+	// Option defines a functional option for cached find functions.
+	Option func(option)
+
+	// option is an internal interface used to apply configuration.
+	option interface {
+		// SetTTL sets the TTL of found models.
+		SetTTL(time.Duration)
+
+		// SetNegativeTTL sets the TTL of not-found results.
+		SetNegativeTTL(time.Duration)
+
+		// SetScoped sets whether values are keyed by organization.
+		SetScoped(bool)
+	}
+
+	// options holds settings of cached find functions.
+	options struct {
+		ttl         time.Duration
+		negativeTTL time.Duration
+		scoped      bool
+	}
+)
+
+// Find returns the model by key from the cache, calling the decorated find function on a miss.
+func (a *Finder[K, M]) Find(c context.Context, key K) (res_ M, found_ bool, err_ error) {
+	return cache.GetOrLoad(
+		c,
+		a.instance,
+		nil,
+		a.key(c, key),
+		func(c context.Context) (M, bool, error) { return a.find(c, key) },
+		cache.WithLoadTTL(a.options.ttl),
+		cache.WithLoadNegativeTTL(a.options.negativeTTL),
+		cache.WithLoadTags(a.tag(key)),
+		cache.WithLoadVersion(a.generation.Load),
+	)
+}
+
+// Invalidate removes the cached model by key in every organization, so it does not depend on
+// the organization of the context (e.g. events raised by the platform).
+// Loads in progress during the invalidation do not cache their possibly stale results.
+func (a *Finder[K, M]) Invalidate(c context.Context, key K) error {
+	a.generation.Add(1)
+	return a.instance.InvalidateTag(c, a.tag(key))
+}
+
+// Close unregisters the invalidation handler; the cache is no longer kept consistent afterward.
+func (a *Finder[K, M]) Close() { a.registration.Remove() }
+
+// key returns the cache key of the lookup key (e.g. "agent.byid:42:100500").
+// Keys are strings, so they can be propagated by cache/bus.
+func (a *Finder[K, M]) key(c context.Context, key K) string {
+	if !a.options.scoped {
+		return a.name + ":" + format(key)
+	}
+	return a.name + ":" + strconv.FormatInt(a.orgClient.IDFromContext(c), 10) + ":" + format(key)
+}
+
+// tag returns the invalidation tag of the lookup key in every organization (e.g. "agent.byid:100500").
+func (a *Finder[K, M]) tag(key K) string {
+	return a.name + ":" + format(key)
+}
+
+// FindByID returns the model by ID (implements find-by-ID client interfaces).
+func (a *ByIDClient[M]) FindByID(c context.Context, id int64) (res_ M, found_ bool, err_ error) {
+	return a.Find(c, id)
+}
+
+// FindByLookupKey returns the model by lookup key (implements find-by-lookup-key client interfaces).
+func (a *ByLookupKeyClient[M]) FindByLookupKey(c context.Context, key string) (res_ M, found_ bool, err_ error) {
+	return a.Find(c, key)
+}
+
+// format returns the lookup key as a string.
+func format[K Key](key K) string {
+	switch v := any(key).(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case string:
+		return v
+	}
+	return ""
+}
+
+func (a *options) SetTTL(v time.Duration)         { a.ttl = v }
+func (a *options) SetNegativeTTL(v time.Duration) { a.negativeTTL = v }
+func (a *options) SetScoped(v bool)               { a.scoped = v }
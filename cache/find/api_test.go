@@ -0,0 +1,148 @@
+package find
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hypershadow-io/contract/cache"
+	"github.com/hypershadow-io/contract/hook"
+)
+
+type (
+	// memory is a minimal in-process cache.Instance for tests.
+	memory struct {
+		locker sync.Mutex
+		items  map[any]any
+		tags   map[any][]string
+	}
+
+	// memorySet collects set options.
+	memorySet struct {
+		tags []string
+	}
+
+	// events is a minimal event registry for tests.
+	events[M any] struct {
+		filter  hook.Filter[M]
+		handler hook.EventFunc[M]
+	}
+
+	// organizations places every context in the same organization.
+	organizations struct{}
+
+	// findFunc adapts a function to a find-by-ID client.
+	findFunc func(c context.Context, id int64) (*agent, bool, error)
+
+	// agent is a cached model.
+	agent struct {
+		id   int64
+		name string
+	}
+)
+
+func (a *memory) Get(_ context.Context, _ func() error, key any) (any, bool, error) {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	value, ok := a.items[key]
+	return value, ok, nil
+}
+
+func (a *memory) Set(_ context.Context, key any, value any, opts ...cache.SetOption) error {
+	o := &memorySet{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	a.items[key] = value
+	a.tags[key] = o.tags
+	return nil
+}
+
+func (a *memory) Delete(_ context.Context, key any) error {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	delete(a.items, key)
+	return nil
+}
+
+func (a *memory) InvalidateTag(_ context.Context, tags ...string) error {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	for key, keyTags := range a.tags {
+		for _, tag := range tags {
+			if slices.Contains(keyTags, tag) {
+				delete(a.items, key)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *memory) DeletePrefix(context.Context, string) error { return nil }
+
+func (a *memorySet) SetTTL(time.Duration) {}
+func (a *memorySet) SetTags(v ...string)  { a.tags = v }
+
+func (a *events[M]) Add(filter hook.Filter[M], handler hook.EventFunc[M]) hook.Registration[hook.EventFunc[M], M] {
+	a.filter, a.handler = filter, handler
+	return nil
+}
+
+// raise calls the handler if its filter matches the kinds.
+func (a *events[M]) raise(t *testing.T, kinds hook.Kinds, value M) {
+	t.Helper()
+	if !a.filter(context.Background(), kinds, value) {
+		return
+	}
+	if err := a.handler(context.Background(), kinds, value); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (organizations) IDFromContext(context.Context) int64                    { return 0 }
+func (organizations) IDToContext(c context.Context, _ int64) context.Context { return c }
+
+func (a findFunc) FindByID(c context.Context, id int64) (*agent, bool, error) { return a(c, id) }
+
+func (a *agent) GetID() int64 { return a.id }
+
+func TestByID_InvalidatesAfterChange(t *testing.T) {
+	c := context.Background()
+	stored := &agent{id: 1, name: "before"}
+	calls := 0
+	client := findFunc(func(context.Context, int64) (*agent, bool, error) {
+		calls++
+		return stored, true, nil
+	})
+	registry := &events[*agent]{}
+	instance := &memory{items: make(map[any]any), tags: make(map[any][]string)}
+	finder := ByID[*agent]("agent.byid", client, instance, organizations{}, registry)
+
+	find := func(want string) {
+		t.Helper()
+		value, found, err := finder.FindByID(c, 1)
+		if err != nil || !found || value.name != want {
+			t.Fatalf("FindByID() = %+v, %v, %v, want %q", value, found, err, want)
+		}
+	}
+	find("before")
+
+	// the update is announced, but not applied yet: a load in between still sees the previous model
+	changed := &agent{id: 1, name: "after"}
+	registry.raise(t, hook.NewKinds(hook.KindBefore, hook.KindUpdate), changed)
+	find("before")
+	if calls != 1 {
+		t.Errorf("loader calls after Before = %d, want 1", calls)
+	}
+
+	stored = changed
+	registry.raise(t, hook.NewKinds(hook.KindAfter, hook.KindUpdate), changed)
+	find("after")
+	if calls != 2 {
+		t.Errorf("loader calls after After = %d, want 2", calls)
+	}
+}
@@ -0,0 +1,9 @@
+module github.com/hypershadow-io/contract/cache/find
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/cache v1.2.0
+	github.com/hypershadow-io/contract/hook v1.1.0
+	github.com/hypershadow-io/contract/organization/ctx v1.0.0
+)
@@ -0,0 +1,282 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// GetOrLoad retrieves a value of type T from the cache, calling the loader on a miss and caching its result.
+// Concurrent calls for the same key on the same instance share a single loader call (request coalescing).
+// The shared call is detached from the cancellation of the caller that started it;
+// every caller (including the first one) stops waiting when its own context is done.
+// The loader follows the found_ convention of the find clients: found = false means the value does not exist,
+// which can be cached as well (see WithLoadNegativeTTL). Loader errors are never cached.
+//
+// Values are stored as LoadEntry[T], so keys loaded by GetOrLoad must not be written by Set directly,
+// and instances decoding values into a proto type (e.g. cache/tiered) must use the proto LoadEntry[T]{}.
+// A cached value of another type fails with ErrLoadEntryType instead of being reloaded on every call.
+// Keys must be comparable.
+func GetOrLoad[T any](
+	c context.Context,
+	instance Instance,
+	errBuilder func() error,
+	key any,
+	loader Loader[T],
+	opts ...LoadOption,
+) (res_ T, found_ bool, err_ error) {
+	o := &loadOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	cached, found, err := instance.Get(c, errBuilder, key)
+	if err != nil {
+		return res_, false, err
+	}
+	if found {
+		entry, ok := cached.(LoadEntry[T])
+		if !ok {
+			return res_, false, ErrLoadEntryType
+		}
+		if !entry.FreshUntil.IsZero() && time.Now().After(entry.FreshUntil) {
+			// stale-while-revalidate: serve the stale value and refresh it in the background
+			start(c, instance, key, loader, o)
+		}
+		return entry.Value, entry.Found, nil
+	}
+	entry, err := load(c, instance, key, loader, o)
+	if err != nil {
+		return res_, false, err
+	}
+	return entry.Value, entry.Found, nil
+}
+
+// WithLoadTTL sets the TTL of loaded values (the instance default if zero).
+func WithLoadTTL(v time.Duration) LoadOption {
+	return func(opt loadOption) { opt.SetTTL(v) }
+}
+
+// WithLoadNegativeTTL enables caching of not-found results for the given duration.
+func WithLoadNegativeTTL(v time.Duration) LoadOption {
+	return func(opt loadOption) { opt.SetNegativeTTL(v) }
+}
+
+// WithLoadStale enables stale-while-revalidate: after the TTL expires, the value is still served
+// for the given duration while it is reloaded in the background. Requires a non-zero TTL.
+func WithLoadStale(v time.Duration) LoadOption {
+	return func(opt loadOption) { opt.SetStale(v) }
+}
+
+// WithLoadJitter randomizes TTLs by up to the given fraction (e.g. 0.1 for ±10%),
+// spreading the expiration of entries cached at the same time. The fraction is capped at maxLoadJitter.
+func WithLoadJitter(v float64) LoadOption {
+	return func(opt loadOption) { opt.SetJitter(v) }
+}
+
+// WithLoadTags attaches invalidation tags to loaded values (see cache.WithSetTags).
+func WithLoadTags(v ...string) LoadOption {
+	return func(opt loadOption) { opt.SetTags(v...) }
+}
+
+// WithLoadVersion guards loaded values against invalidations racing with the loader call.
+// The version is read before the loader call and after caching its result; if it changed,
+// the cached value is removed again. Invalidations must change the version before removing values.
+//
+// Example:
+//
+//	cache.WithLoadVersion(generation.Load) // with generation.Add(1) before every instance.Delete
+func WithLoadVersion(v func() uint64) LoadOption {
+	return func(opt loadOption) { opt.SetVersion(v) }
+}
+
+type (
+	// Loader loads a value on a cache miss.
+	// Returns the value, a boolean indicating whether it was found, and an error if occurred.
+	Loader[T any] func(c context.Context) (res_ T, found_ bool, err_ error)
+
+	// LoadEntry is the cached representation of a loaded value.
+	LoadEntry[T any] struct {
+		Value      T         // loaded value
+		Found      bool      // whether the value exists
+		FreshUntil time.Time // end of the fresh period if stale-while-revalidate is enabled
+	}
+
+	// LoadOption defines a function used to configure GetOrLoad behavior.
+	LoadOption func(loadOption)
+
+	// loadOption defines internal configuration methods for GetOrLoad options.
+	loadOption interface {
+		// SetTTL sets the TTL of loaded values.
+		SetTTL(time.Duration)
+
+		// SetNegativeTTL sets the TTL of not-found results.
+		SetNegativeTTL(time.Duration)
+
+		// SetStale sets the stale-while-revalidate period.
+		SetStale(time.Duration)
+
+		// SetJitter sets the TTL jitter fraction.
+		SetJitter(float64)
+
+		// SetTags sets the invalidation tags of loaded values.
+		SetTags(...string)
+
+		// SetVersion sets the version function guarding loaded values against racing invalidations.
+		SetVersion(func() uint64)
+	}
+
+	// loadOptions holds GetOrLoad settings.
+	loadOptions struct {
+		ttl         time.Duration
+		negativeTTL time.Duration
+		stale       time.Duration
+		jitter      float64
+		tags        []string
+		version     func() uint64
+	}
+
+	// flight is an in-progress loader call shared by concurrent callers.
+	flight struct {
+		done  chan struct{}
+		entry any // LoadEntry[T]
+		err   error
+	}
+
+	// flightKey identifies a loader call by the cache instance and key.
+	flightKey struct {
+		instance Instance
+		key      any
+	}
+)
+
+var (
+	// ErrLoadAborted is returned to callers of a loader call that panicked.
+	ErrLoadAborted = errors.New("cache: loader aborted")
+
+	// ErrLoadEntryType is returned when the cached value of a key loaded by GetOrLoad is not a LoadEntry[T],
+	// e.g. when a two-tier instance decodes values from L2 into another proto type.
+	ErrLoadEntryType = errors.New("cache: cached value is not a load entry")
+)
+
+// maxLoadJitter caps the jitter fraction, so that jittered TTLs stay positive.
+const maxLoadJitter = 0.9
+
+// flights holds in-progress loader calls.
+var flights = struct {
+	sync.Mutex
+	items map[flightKey]*flight
+}{items: make(map[flightKey]*flight)}
+
+// load waits for the shared loader call of the instance and key, starting it if none is in progress.
+func load[T any](
+	c context.Context,
+	instance Instance,
+	key any,
+	loader Loader[T],
+	o *loadOptions,
+) (LoadEntry[T], error) {
+	current := start(c, instance, key, loader, o)
+	select {
+	case <-current.done:
+	case <-c.Done():
+		return LoadEntry[T]{}, c.Err()
+	}
+	if current.err != nil {
+		return LoadEntry[T]{}, current.err
+	}
+	return current.entry.(LoadEntry[T]), nil
+}
+
+// start returns the in-progress loader call of the instance and key,
+// or starts a new one in the background, detached from the cancellation of the context.
+func start[T any](
+	c context.Context,
+	instance Instance,
+	key any,
+	loader Loader[T],
+	o *loadOptions,
+) *flight {
+	k := flightKey{instance: instance, key: key}
+	flights.Lock()
+	defer flights.Unlock()
+	if current, ok := flights.items[k]; ok {
+		return current
+	}
+	current := &flight{done: make(chan struct{}), err: ErrLoadAborted}
+	flights.items[k] = current
+	go func() {
+		defer func() {
+			_ = recover() // waiters receive ErrLoadAborted
+			flights.Lock()
+			delete(flights.items, k)
+			flights.Unlock()
+			close(current.done)
+		}()
+		current.entry, current.err = run(context.WithoutCancel(c), instance, key, loader, o)
+	}()
+	return current
+}
+
+// run calls the loader and caches its result.
+func run[T any](
+	c context.Context,
+	instance Instance,
+	key any,
+	loader Loader[T],
+	o *loadOptions,
+) (LoadEntry[T], error) {
+	var version uint64
+	if o.version != nil {
+		version = o.version()
+	}
+	var entry LoadEntry[T]
+	var err error
+	entry.Value, entry.Found, err = loader(c)
+	if err != nil {
+		return entry, err
+	}
+	ttl := o.ttl
+	if !entry.Found {
+		if o.negativeTTL <= 0 {
+			return entry, nil
+		}
+		ttl = o.negativeTTL
+	}
+	ttl = o.jittered(ttl)
+	if entry.Found && ttl > 0 && o.stale > 0 {
+		entry.FreshUntil = time.Now().Add(ttl)
+		ttl += o.stale
+	}
+	var setOpts []SetOption
+	if ttl > 0 {
+		setOpts = append(setOpts, WithSetTTL(ttl))
+	}
+	if len(o.tags) > 0 {
+		setOpts = append(setOpts, WithSetTags(o.tags...))
+	}
+	// the value is loaded; failing to cache it must not fail the caller
+	_ = instance.Set(c, key, entry, setOpts...)
+	if o.version != nil && o.version() != version {
+		// the value was invalidated during the load and may be stale
+		_ = instance.Delete(c, key)
+	}
+	return entry, nil
+}
+
+// jittered randomizes the TTL by up to the jitter fraction.
+func (a *loadOptions) jittered(ttl time.Duration) time.Duration {
+	if a.jitter <= 0 {
+		return ttl
+	}
+	delta := float64(ttl) * min(a.jitter, maxLoadJitter)
+	return ttl + time.Duration(delta*(2*rand.Float64()-1))
+}
+
+func (a *loadOptions) SetTTL(v time.Duration)         { a.ttl = v }
+func (a *loadOptions) SetNegativeTTL(v time.Duration) { a.negativeTTL = v }
+func (a *loadOptions) SetStale(v time.Duration)       { a.stale = v }
+func (a *loadOptions) SetJitter(v float64)            { a.jitter = v }
+func (a *loadOptions) SetTags(v ...string)            { a.tags = v }
+func (a *loadOptions) SetVersion(v func() uint64)     { a.version = v }
@@ -0,0 +1,235 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type (
+	// memory is a minimal in-process Instance for tests.
+	memory struct {
+		locker sync.Mutex
+		items  map[any]any
+		ttls   map[any]time.Duration
+	}
+
+	// memorySet collects set options.
+	memorySet struct {
+		ttl time.Duration
+	}
+)
+
+func newMemory() *memory {
+	return &memory{items: make(map[any]any), ttls: make(map[any]time.Duration)}
+}
+
+func (a *memory) Get(_ context.Context, _ func() error, key any) (any, bool, error) {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	value, ok := a.items[key]
+	return value, ok, nil
+}
+
+func (a *memory) Set(_ context.Context, key any, value any, opts ...SetOption) error {
+	o := &memorySet{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	a.items[key] = value
+	a.ttls[key] = o.ttl
+	return nil
+}
+
+func (a *memory) Delete(_ context.Context, key any) error {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	delete(a.items, key)
+	return nil
+}
+
+func (a *memory) InvalidateTag(context.Context, ...string) error { return nil }
+func (a *memory) DeletePrefix(context.Context, string) error     { return nil }
+
+func (a *memory) ttl(key any) time.Duration {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	return a.ttls[key]
+}
+
+func (a *memorySet) SetTTL(v time.Duration) { a.ttl = v }
+func (a *memorySet) SetTags(...string)      {}
+
+func TestGetOrLoad_Coalescing(t *testing.T) {
+	instance := newMemory()
+	release := make(chan struct{})
+	var calls atomic.Int32
+	loader := func(c context.Context) (int, bool, error) {
+		calls.Add(1)
+		<-release
+		return 42, true, c.Err()
+	}
+
+	// the first caller gives up, the shared call must still complete for the others
+	leader, cancel := context.WithCancel(context.Background())
+	leaderDone := make(chan error, 1)
+	go func() {
+		_, _, err := GetOrLoad(leader, instance, nil, "key", loader)
+		leaderDone <- err
+	}()
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	var wg sync.WaitGroup
+	results := make([]int, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, _, err := GetOrLoad(context.Background(), instance, nil, "key", loader)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = value
+		}()
+	}
+	cancel()
+	if err := <-leaderDone; !errors.Is(err, context.Canceled) {
+		t.Fatalf("leader error = %v, want %v", err, context.Canceled)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loader calls = %d, want 1", n)
+	}
+	for _, value := range results {
+		if value != 42 {
+			t.Errorf("value = %d, want 42", value)
+		}
+	}
+	if _, found, _ := instance.Get(context.Background(), nil, "key"); !found {
+		t.Error("loaded value was not cached")
+	}
+}
+
+func TestGetOrLoad_Negative(t *testing.T) {
+	instance := newMemory()
+	var calls atomic.Int32
+	loader := func(context.Context) (int, bool, error) {
+		calls.Add(1)
+		return 0, false, nil
+	}
+
+	for range 2 {
+		if _, found, err := GetOrLoad(context.Background(), instance, nil, "missing", loader); err != nil || found {
+			t.Fatalf("GetOrLoad() = %v, %v, want not found", found, err)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("loader calls without negative caching = %d, want 2", n)
+	}
+
+	calls.Store(0)
+	for range 2 {
+		if _, found, err := GetOrLoad(context.Background(), instance, nil, "negative", loader, WithLoadNegativeTTL(time.Minute)); err != nil || found {
+			t.Fatalf("GetOrLoad() = %v, %v, want not found", found, err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loader calls with negative caching = %d, want 1", n)
+	}
+	if ttl := instance.ttl("negative"); ttl != time.Minute {
+		t.Errorf("negative TTL = %v, want %v", ttl, time.Minute)
+	}
+}
+
+func TestGetOrLoad_Stale(t *testing.T) {
+	instance := newMemory()
+	_ = instance.Set(context.Background(), "key", LoadEntry[int]{Value: 1, Found: true, FreshUntil: time.Now().Add(-time.Second)})
+	refreshed := make(chan struct{})
+	loader := func(context.Context) (int, bool, error) {
+		defer close(refreshed)
+		return 2, true, nil
+	}
+
+	value, found, err := GetOrLoad(context.Background(), instance, nil, "key", loader, WithLoadTTL(time.Minute), WithLoadStale(time.Hour))
+	if err != nil || !found || value != 1 {
+		t.Fatalf("GetOrLoad() = %d, %v, %v, want the stale value", value, found, err)
+	}
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("stale value was not refreshed")
+	}
+	for deadline := time.Now().Add(time.Second); ; {
+		cached, _, _ := instance.Get(context.Background(), nil, "key")
+		entry := cached.(LoadEntry[int])
+		if entry.Value == 2 {
+			if !entry.FreshUntil.After(time.Now()) {
+				t.Errorf("FreshUntil = %v, want a future time", entry.FreshUntil)
+			}
+			if ttl := instance.ttl("key"); ttl != time.Minute+time.Hour {
+				t.Errorf("TTL = %v, want %v", ttl, time.Minute+time.Hour)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("refreshed value was not cached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestGetOrLoad_Version(t *testing.T) {
+	instance := newMemory()
+	var version atomic.Uint64
+	loader := func(context.Context) (int, bool, error) {
+		// an invalidation racing with the load
+		version.Add(1)
+		_ = instance.Delete(context.Background(), "key")
+		return 1, true, nil
+	}
+
+	value, found, err := GetOrLoad(context.Background(), instance, nil, "key", loader, WithLoadVersion(version.Load))
+	if err != nil || !found || value != 1 {
+		t.Fatalf("GetOrLoad() = %d, %v, %v, want the loaded value", value, found, err)
+	}
+	if _, found, _ = instance.Get(context.Background(), nil, "key"); found {
+		t.Error("value invalidated during the load was cached")
+	}
+}
+
+func TestGetOrLoad_Panic(t *testing.T) {
+	instance := newMemory()
+	loader := func(context.Context) (int, bool, error) { panic("boom") }
+
+	if _, _, err := GetOrLoad(context.Background(), instance, nil, "key", loader); !errors.Is(err, ErrLoadAborted) {
+		t.Errorf("GetOrLoad() error = %v, want %v", err, ErrLoadAborted)
+	}
+}
+
+func TestGetOrLoad_EntryType(t *testing.T) {
+	instance := newMemory()
+	_ = instance.Set(context.Background(), "key", 1)
+	loader := func(context.Context) (int, bool, error) { return 2, true, nil }
+
+	if _, _, err := GetOrLoad(context.Background(), instance, nil, "key", loader); !errors.Is(err, ErrLoadEntryType) {
+		t.Errorf("GetOrLoad() error = %v, want %v", err, ErrLoadEntryType)
+	}
+}
+
+func TestLoadOptions_Jitter(t *testing.T) {
+	o := &loadOptions{jitter: 5}
+	for range 100 {
+		if ttl := o.jittered(time.Second); ttl <= 0 || ttl >= 2*time.Second {
+			t.Fatalf("jittered TTL = %v, want within (0, 2s)", ttl)
+		}
+	}
+}
@@ -10,6 +10,8 @@ type (
 	// Builder defines the interface for creating a new local in-memory cache instance.
 	Builder interface {
 		// NewInstance creates a new in-memory cache with optional configuration options.
+		// Implementations index tagged values, so that InvalidateTag costs O(values with the tag).
+		// Instances created by implementations of this package also implement StatsGetter.
 		NewInstance(ttl time.Duration, opts ...NewInstanceOption) (cache.Instance, error)
 	}
 
@@ -23,9 +25,93 @@ type (
 
 		// SetOnDelete registers a callback function that is invoked when an entry is deleted from the cache.
 		SetOnDelete(func(any)) error
+
+		// SetMaxEntries sets the maximum number of entries kept in the cache.
+		SetMaxEntries(int) error
+
+		// SetMaxCost sets the maximum total cost of entries kept in the cache.
+		SetMaxCost(int64) error
+
+		// SetCost sets the function computing the cost of an entry.
+		SetCost(CostFunc) error
+
+		// SetEviction sets the built-in policy choosing entries to evict when a bound is reached.
+		SetEviction(Eviction) error
+
+		// SetPolicy sets a custom policy choosing entries to evict when a bound is reached.
+		SetPolicy(PolicyFactory) error
+	}
+
+	// CostFunc computes the cost of an entry (e.g. its approximate size in bytes).
+	CostFunc func(key any, value any) int64
+
+	// Eviction is a built-in policy choosing entries to evict when the cache reaches its bounds.
+	Eviction int
+
+	// Policy tracks the usage of entries and chooses entries to evict when the cache reaches its bounds.
+	// Instances serialize calls to the policy, so implementations do not need to be safe for concurrent use.
+	Policy interface {
+		// Add records a new entry with the key.
+		Add(key any)
+
+		// Access records a hit of the entry with the key.
+		Access(key any)
+
+		// Remove forgets the entry with the key (deleted, expired or evicted).
+		Remove(key any)
+
+		// Victim returns the key of the entry to evict next; it must be a key added and not yet removed.
+		// Returns false if there are no entries.
+		Victim() (key_ any, ok_ bool)
+
+		// Admit reports whether a new entry with the key may replace the victim entry.
+		// Return true to always admit new entries.
+		Admit(key any, victim any) bool
+
+		// Record registers a lookup or a write of the key, including misses (e.g. to estimate key frequencies).
+		Record(key any)
+	}
+
+	// PolicyFactory creates the Policy of a new instance.
+	// The capacity is the maximum number of entries, or zero if the instance is bounded only by cost.
+	PolicyFactory func(capacity int) Policy
+
+	// StatsGetter is implemented by cache instances that collect statistics.
+	StatsGetter interface {
+		// Stats returns a snapshot of the instance statistics.
+		Stats() Stats
+	}
+
+	// Stats is a snapshot of cache instance statistics.
+	Stats struct {
+		Hits      uint64 `json:"hits"`      // number of lookups that found a value
+		Misses    uint64 `json:"misses"`    // number of lookups that found no value
+		Evictions uint64 `json:"evictions"` // number of entries removed or rejected to keep the cache within its bounds
+		Size      int    `json:"size"`      // current number of entries
+		Cost      int64  `json:"cost"`      // current total cost of entries
 	}
 )
 
+const (
+	// EvictionLRU evicts the least recently used entry (the default).
+	EvictionLRU Eviction = iota
+
+	// EvictionLFU evicts the least frequently used entry; ties are broken by recency.
+	EvictionLFU
+
+	// EvictionTinyLFU evicts the least recently used entry, but admits a new entry only if it is estimated
+	// to be accessed more frequently than the entry it would evict. Resists scans and one-off keys.
+	EvictionTinyLFU
+)
+
+// GetStats returns the statistics of the instance if it collects them.
+func GetStats(instance cache.Instance) (Stats, bool) {
+	if getter, ok := instance.(StatsGetter); ok {
+		return getter.Stats(), true
+	}
+	return Stats{}, false
+}
+
 // WithNewInstanceCleanupTTL configures the cache to periodically clean up expired entries.
 func WithNewInstanceCleanupTTL(v time.Duration) NewInstanceOption {
 	return func(opt newInstanceOption) error { return opt.SetCleanupTTL(v) }
@@ -35,3 +121,38 @@ func WithNewInstanceCleanupTTL(v time.Duration) NewInstanceOption {
 func WithNewInstanceOnDelete(v func(any)) NewInstanceOption {
 	return func(opt newInstanceOption) error { return opt.SetOnDelete(v) }
 }
+
+// WithNewInstanceMaxEntries bounds the number of entries; zero means unbounded.
+func WithNewInstanceMaxEntries(v int) NewInstanceOption {
+	return func(opt newInstanceOption) error { return opt.SetMaxEntries(v) }
+}
+
+// WithNewInstanceMaxCost bounds the total cost of entries; zero means unbounded.
+// Every entry costs 1 unless a cost function is configured (see WithNewInstanceCost).
+func WithNewInstanceMaxCost(v int64) NewInstanceOption {
+	return func(opt newInstanceOption) error { return opt.SetMaxCost(v) }
+}
+
+// WithNewInstanceCost configures the function computing the cost of each entry.
+//
+// Example:
+//
+//	local.WithNewInstanceCost(func(_ any, value any) int64 { return int64(len(value.([]byte))) })
+func WithNewInstanceCost(v CostFunc) NewInstanceOption {
+	return func(opt newInstanceOption) error { return opt.SetCost(v) }
+}
+
+// WithNewInstanceEviction configures the built-in eviction policy (EvictionLRU by default).
+func WithNewInstanceEviction(v Eviction) NewInstanceOption {
+	return func(opt newInstanceOption) error { return opt.SetEviction(v) }
+}
+
+// WithNewInstancePolicy configures a custom eviction policy; it takes precedence over WithNewInstanceEviction.
+// The policy is used only if the instance is bounded (see WithNewInstanceMaxEntries and WithNewInstanceMaxCost).
+//
+// Example:
+//
+//	local.WithNewInstancePolicy(func(capacity int) local.Policy { return newFIFO(capacity) })
+func WithNewInstancePolicy(v PolicyFactory) NewInstanceOption {
+	return func(opt newInstanceOption) error { return opt.SetPolicy(v) }
+}
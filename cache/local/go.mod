@@ -2,4 +2,4 @@ module github.com/hypershadow-io/contract/cache/local
 
 go 1.24.0
 
-require github.com/hypershadow-io/contract/cache v1.1.0
+require github.com/hypershadow-io/contract/cache v1.2.0
@@ -0,0 +1,381 @@
+package impl
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hypershadow-io/contract/cache"
+	"github.com/hypershadow-io/contract/cache/local"
+)
+
+// New returns the default local.Builder creating in-memory cache instances.
+func New() local.Builder { return builder{} }
+
+type (
+	// builder is the default local.Builder implementation.
+	builder struct{}
+
+	// Instance is an in-memory cache instance.
+	// Entries are expired lazily on access and, if configured, by a periodic cleanup.
+	// If the instance is bounded, entries are evicted by the configured policy.
+	Instance struct {
+		*store
+	}
+
+	// store holds the cache data; it is separated from Instance so that the cleanup goroutine
+	// does not keep the Instance reachable and stops once the Instance is garbage collected.
+	store struct {
+		locker     sync.Mutex
+		ttl        time.Duration
+		items      map[any]*item
+		tags       map[string]map[any]struct{} // keys by tag
+		onDelete   func(any)
+		stop       chan struct{}
+		maxEntries int
+		maxCost    int64
+		costFunc   local.CostFunc
+		cost       int64
+		policy     policy // nil if the instance is unbounded
+		hits       atomic.Uint64
+		misses     atomic.Uint64
+		evictions  atomic.Uint64
+	}
+
+	// item is a cached value.
+	item struct {
+		key      any
+		value    any
+		expires  time.Time // zero if the value never expires
+		tags     []string
+		cost     int64
+		element  *list.Element // position in the LRU order
+		index    int           // position in the LFU heap
+		hits     uint64        // LFU access count
+		accessed uint64        // LFU logical time of the last access
+	}
+
+	// instanceOptions holds settings of a new instance.
+	instanceOptions struct {
+		cleanupTTL time.Duration
+		onDelete   func(any)
+		maxEntries int
+		maxCost    int64
+		cost       local.CostFunc
+		eviction   local.Eviction
+		policy     local.PolicyFactory
+	}
+
+	// setOptions holds settings of a single Set call.
+	setOptions struct {
+		ttl  time.Duration
+		tags []string
+	}
+)
+
+func (builder) NewInstance(ttl time.Duration, opts ...local.NewInstanceOption) (cache.Instance, error) {
+	o := &instanceOptions{}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	s := &store{
+		ttl:        ttl,
+		items:      make(map[any]*item),
+		tags:       make(map[string]map[any]struct{}),
+		onDelete:   o.onDelete,
+		maxEntries: o.maxEntries,
+		maxCost:    o.maxCost,
+		costFunc:   o.cost,
+	}
+	if o.maxEntries > 0 || o.maxCost > 0 {
+		s.policy = newPolicy(o.eviction, o.maxEntries)
+		if o.policy != nil {
+			s.policy = &custom{policy: o.policy(o.maxEntries), items: s.items}
+		}
+	}
+	result := &Instance{store: s}
+	if o.cleanupTTL > 0 {
+		s.stop = make(chan struct{})
+		go s.cleanup(o.cleanupTTL)
+		runtime.AddCleanup(result, func(stop chan struct{}) { close(stop) }, s.stop)
+	}
+	return result, nil
+}
+
+func (a *store) Get(_ context.Context, _ func() error, key any) (res_ any, found_ bool, err_ error) {
+	a.locker.Lock()
+	if a.policy != nil {
+		a.policy.record(key)
+	}
+	e, ok := a.items[key]
+	if ok && e.expired(time.Now()) {
+		a.remove(e)
+		a.locker.Unlock()
+		a.misses.Add(1)
+		a.deleted(e)
+		return nil, false, nil
+	}
+	if ok && a.policy != nil {
+		a.policy.access(e)
+	}
+	a.locker.Unlock()
+	if !ok {
+		a.misses.Add(1)
+		return nil, false, nil
+	}
+	a.hits.Add(1)
+	return e.value, true, nil
+}
+
+func (a *store) Set(_ context.Context, key any, value any, opts ...cache.SetOption) error {
+	o := &setOptions{ttl: a.ttl}
+	for _, opt := range opts {
+		opt(o)
+	}
+	e := &item{key: key, value: value, tags: o.tags, cost: 1}
+	if o.ttl > 0 {
+		e.expires = time.Now().Add(o.ttl)
+	}
+	if a.costFunc != nil {
+		e.cost = a.costFunc(key, value)
+	}
+	var removed []*item
+	a.locker.Lock()
+	previous, replaced := a.items[key]
+	if replaced {
+		a.remove(previous)
+		removed = append(removed, previous)
+	}
+	if a.admit(e, replaced) {
+		for a.exceeds(e.cost) {
+			victim := a.policy.victim()
+			if victim == nil {
+				break // a custom policy returned an unknown key
+			}
+			a.remove(victim)
+			a.evictions.Add(1)
+			removed = append(removed, victim)
+		}
+		a.insert(e)
+	} else {
+		a.evictions.Add(1)
+	}
+	a.locker.Unlock()
+	for _, item := range removed {
+		a.deleted(item)
+	}
+	return nil
+}
+
+func (a *store) Delete(_ context.Context, key any) error {
+	a.locker.Lock()
+	e, ok := a.items[key]
+	if ok {
+		a.remove(e)
+	}
+	a.locker.Unlock()
+	if ok {
+		a.deleted(e)
+	}
+	return nil
+}
+
+func (a *store) InvalidateTag(_ context.Context, tags ...string) error {
+	var removed []*item
+	a.locker.Lock()
+	for _, tag := range tags {
+		for key := range a.tags[tag] {
+			if e, ok := a.items[key]; ok {
+				a.remove(e)
+				removed = append(removed, e)
+			}
+		}
+	}
+	a.locker.Unlock()
+	for _, e := range removed {
+		a.deleted(e)
+	}
+	return nil
+}
+
+// DeletePrefix scans all keys, so it costs O(values in the instance).
+func (a *store) DeletePrefix(_ context.Context, prefix string) error {
+	var removed []*item
+	a.locker.Lock()
+	for key, e := range a.items {
+		if s, ok := key.(string); ok && strings.HasPrefix(s, prefix) {
+			a.remove(e)
+			removed = append(removed, e)
+		}
+	}
+	a.locker.Unlock()
+	for _, e := range removed {
+		a.deleted(e)
+	}
+	return nil
+}
+
+// Stats returns a snapshot of the instance statistics (implements local.StatsGetter).
+func (a *store) Stats() local.Stats {
+	a.locker.Lock()
+	size, cost := len(a.items), a.cost
+	a.locker.Unlock()
+	return local.Stats{
+		Hits:      a.hits.Load(),
+		Misses:    a.misses.Load(),
+		Evictions: a.evictions.Load(),
+		Size:      size,
+		Cost:      cost,
+	}
+}
+
+// admit reports whether the new item fits the bounds and is accepted by the policy; must be called under the lock.
+// Replacements of present keys are always accepted by the policy.
+func (a *store) admit(e *item, replaced bool) bool {
+	if a.policy == nil {
+		return true
+	}
+	a.policy.record(e.key)
+	if a.maxCost > 0 && e.cost > a.maxCost {
+		return false
+	}
+	if replaced || !a.exceeds(e.cost) {
+		return true
+	}
+	victim := a.policy.victim()
+	return victim == nil || a.policy.admit(e.key, victim)
+}
+
+// exceeds reports whether adding an item of the given cost breaks the bounds; must be called under the lock.
+func (a *store) exceeds(cost int64) bool {
+	if a.policy == nil || len(a.items) == 0 {
+		return false
+	}
+	return (a.maxEntries > 0 && len(a.items) >= a.maxEntries) || (a.maxCost > 0 && a.cost+cost > a.maxCost)
+}
+
+// insert adds the item and its tag index entries; must be called under the lock.
+func (a *store) insert(e *item) {
+	a.items[e.key] = e
+	a.cost += e.cost
+	if a.policy != nil {
+		a.policy.add(e)
+	}
+	for _, tag := range e.tags {
+		keys, ok := a.tags[tag]
+		if !ok {
+			keys = make(map[any]struct{})
+			a.tags[tag] = keys
+		}
+		keys[e.key] = struct{}{}
+	}
+}
+
+// remove deletes the item and its tag index entries; must be called under the lock.
+func (a *store) remove(e *item) {
+	delete(a.items, e.key)
+	a.cost -= e.cost
+	if a.policy != nil {
+		a.policy.remove(e)
+	}
+	for _, tag := range e.tags {
+		if keys, ok := a.tags[tag]; ok {
+			delete(keys, e.key)
+			if len(keys) == 0 {
+				delete(a.tags, tag)
+			}
+		}
+	}
+}
+
+// deleted invokes the on-delete callback with the removed value; must be called outside the lock.
+func (a *store) deleted(e *item) {
+	if a.onDelete != nil {
+		a.onDelete(e.value)
+	}
+}
+
+// cleanup periodically removes expired items until the instance is garbage collected.
+func (a *store) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.stop:
+			return
+		case now := <-ticker.C:
+			var removed []*item
+			a.locker.Lock()
+			for _, e := range a.items {
+				if e.expired(now) {
+					a.remove(e)
+					removed = append(removed, e)
+				}
+			}
+			a.locker.Unlock()
+			for _, e := range removed {
+				a.deleted(e)
+			}
+		}
+	}
+}
+
+// expired reports whether the item is expired at the given time.
+func (a *item) expired(now time.Time) bool {
+	return !a.expires.IsZero() && now.After(a.expires)
+}
+
+func (a *instanceOptions) SetCleanupTTL(v time.Duration) error {
+	if v < 0 {
+		return errors.New("cache: negative cleanup interval")
+	}
+	a.cleanupTTL = v
+	return nil
+}
+func (a *instanceOptions) SetOnDelete(v func(any)) error {
+	a.onDelete = v
+	return nil
+}
+func (a *instanceOptions) SetMaxEntries(v int) error {
+	if v < 0 {
+		return errors.New("cache: negative max entries")
+	}
+	a.maxEntries = v
+	return nil
+}
+func (a *instanceOptions) SetMaxCost(v int64) error {
+	if v < 0 {
+		return errors.New("cache: negative max cost")
+	}
+	a.maxCost = v
+	return nil
+}
+func (a *instanceOptions) SetCost(v local.CostFunc) error {
+	a.cost = v
+	return nil
+}
+func (a *instanceOptions) SetEviction(v local.Eviction) error {
+	if v < local.EvictionLRU || v > local.EvictionTinyLFU {
+		return errors.New("cache: unknown eviction policy")
+	}
+	a.eviction = v
+	return nil
+}
+
+func (a *instanceOptions) SetPolicy(v local.PolicyFactory) error {
+	if v == nil {
+		return errors.New("cache: nil eviction policy")
+	}
+	a.policy = v
+	return nil
+}
+
+func (a *setOptions) SetTTL(v time.Duration) { a.ttl = v }
+func (a *setOptions) SetTags(v ...string)    { a.tags = v }
@@ -0,0 +1,185 @@
+package impl
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hypershadow-io/contract/cache"
+	"github.com/hypershadow-io/contract/cache/local"
+)
+
+// fifo is a custom policy evicting entries in insertion order.
+type fifo struct {
+	keys    []any
+	removed []any
+}
+
+func (a *fifo) Add(key any)         { a.keys = append(a.keys, key) }
+func (a *fifo) Access(any)          {}
+func (a *fifo) Admit(any, any) bool { return true }
+func (a *fifo) Record(any)          {}
+func (a *fifo) Remove(key any) {
+	a.keys = slices.DeleteFunc(a.keys, func(v any) bool { return v == key })
+	a.removed = append(a.removed, key)
+}
+func (a *fifo) Victim() (any, bool) {
+	if len(a.keys) == 0 {
+		return nil, false
+	}
+	return a.keys[0], true
+}
+
+func TestInstance_EvictionLRU(t *testing.T) {
+	c := context.Background()
+	instance := newInstance(t, local.WithNewInstanceMaxEntries(3))
+	set(t, instance, "a", "b", "c")
+	get(t, instance, "a")
+	set(t, instance, "d")
+
+	assertKeys(t, instance, []string{"a", "c", "d"}, []string{"b"})
+	set(t, instance, "e")
+	assertKeys(t, instance, []string{"a", "d", "e"}, []string{"c"})
+	if stats, _ := local.GetStats(instance); stats.Evictions != 2 || stats.Size != 3 {
+		t.Errorf("stats = %+v, want 2 evictions and 3 entries", stats)
+	}
+	_ = instance.Delete(c, "a")
+	set(t, instance, "f")
+	assertKeys(t, instance, []string{"d", "e", "f"}, nil)
+}
+
+func TestInstance_EvictionLFU(t *testing.T) {
+	instance := newInstance(t, local.WithNewInstanceMaxEntries(3), local.WithNewInstanceEviction(local.EvictionLFU))
+	set(t, instance, "a", "b", "c")
+	get(t, instance, "a", "a", "c")
+	set(t, instance, "d")
+
+	assertKeys(t, instance, []string{"a", "c", "d"}, []string{"b"})
+	// d has the fewest hits despite being the most recent entry
+	set(t, instance, "e")
+	assertKeys(t, instance, []string{"a", "c", "e"}, []string{"d"})
+}
+
+func TestInstance_MaxCost(t *testing.T) {
+	c := context.Background()
+	instance := newInstance(t,
+		local.WithNewInstanceMaxCost(10),
+		local.WithNewInstanceCost(func(_ any, value any) int64 { return int64(len(value.(string))) }),
+	)
+	for key, value := range map[string]string{"a": "aaaa", "b": "bbbb"} {
+		if err := instance.Set(c, key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	get(t, instance, "a")
+	if err := instance.Set(c, "c", "cc"); err != nil {
+		t.Fatal(err)
+	}
+	if stats, _ := local.GetStats(instance); stats.Cost != 10 || stats.Evictions != 0 {
+		t.Fatalf("stats = %+v, want cost 10 without evictions", stats)
+	}
+	if err := instance.Set(c, "d", "ddd"); err != nil {
+		t.Fatal(err)
+	}
+	assertKeys(t, instance, []string{"a", "c", "d"}, []string{"b"})
+
+	// an entry exceeding the bound on its own is rejected without evicting others
+	if err := instance.Set(c, "huge", "xxxxxxxxxxx"); err != nil {
+		t.Fatal(err)
+	}
+	assertKeys(t, instance, []string{"a", "c", "d"}, []string{"huge"})
+	if stats, _ := local.GetStats(instance); stats.Cost != 9 || stats.Evictions != 2 {
+		t.Errorf("stats = %+v, want cost 9 and 2 evictions", stats)
+	}
+}
+
+func TestInstance_EvictionTinyLFU(t *testing.T) {
+	instance := newInstance(t, local.WithNewInstanceMaxEntries(2), local.WithNewInstanceEviction(local.EvictionTinyLFU))
+	set(t, instance, "a", "b")
+	for range 5 {
+		get(t, instance, "a", "b")
+	}
+
+	// a one-off key is less popular than the victim and is not admitted
+	set(t, instance, "scan")
+	assertKeys(t, instance, []string{"a", "b"}, []string{"scan"})
+
+	// a key requested often enough replaces the least recently used entry
+	for range 10 {
+		get(t, instance, "popular")
+	}
+	get(t, instance, "b")
+	set(t, instance, "popular")
+	assertKeys(t, instance, []string{"b", "popular"}, []string{"a"})
+}
+
+func TestInstance_CustomPolicy(t *testing.T) {
+	policy := &fifo{}
+	instance := newInstance(t,
+		local.WithNewInstanceMaxEntries(2),
+		local.WithNewInstanceEviction(local.EvictionLFU),
+		local.WithNewInstancePolicy(func(capacity int) local.Policy {
+			if capacity != 2 {
+				t.Errorf("capacity = %d, want 2", capacity)
+			}
+			return policy
+		}),
+	)
+	set(t, instance, "a", "b")
+	get(t, instance, "a", "a")
+	set(t, instance, "c")
+
+	assertKeys(t, instance, []string{"b", "c"}, []string{"a"})
+	if !slices.Equal(policy.removed, []any{"a"}) {
+		t.Errorf("removed = %v, want [a]", policy.removed)
+	}
+	if _, err := New().NewInstance(0, local.WithNewInstancePolicy(nil)); err == nil {
+		t.Error("NewInstance() accepted a nil policy")
+	}
+}
+
+func newInstance(t *testing.T, opts ...local.NewInstanceOption) cache.Instance {
+	t.Helper()
+	instance, err := New().NewInstance(time.Minute, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return instance
+}
+
+func set(t *testing.T, instance cache.Instance, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		if err := instance.Set(context.Background(), key, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func get(t *testing.T, instance cache.Instance, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		if _, _, err := instance.Get(context.Background(), nil, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// assertKeys checks the stored keys without recording usage, so that the eviction order is not affected.
+func assertKeys(t *testing.T, instance cache.Instance, present []string, absent []string) {
+	t.Helper()
+	s := instance.(*Instance).store
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	for _, key := range present {
+		if _, ok := s.items[key]; !ok {
+			t.Errorf("key %q is missing", key)
+		}
+	}
+	for _, key := range absent {
+		if _, ok := s.items[key]; ok {
+			t.Errorf("key %q is present", key)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+module github.com/hypershadow-io/contract/cache/local/impl
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/cache v1.2.0
+	github.com/hypershadow-io/contract/cache/local v1.1.0
+)
@@ -0,0 +1,210 @@
+package impl
+
+import (
+	"container/heap"
+	"container/list"
+	"hash/maphash"
+
+	"github.com/hypershadow-io/contract/cache/local"
+)
+
+type (
+	// policy tracks entry usage and chooses eviction victims; called under the store lock.
+	policy interface {
+		// add records a new entry.
+		add(e *item)
+
+		// access records a hit of the entry.
+		access(e *item)
+
+		// remove forgets the entry.
+		remove(e *item)
+
+		// victim returns the entry to evict next, or nil if there are no entries.
+		victim() *item
+
+		// admit reports whether a new entry with the key may replace the victim.
+		admit(key any, victim *item) bool
+
+		// record registers a lookup or a write of the key, including misses.
+		record(key any)
+	}
+
+	// lru evicts the least recently used entry.
+	lru struct {
+		order *list.List // front is the most recently used
+	}
+
+	// lfu evicts the least frequently used entry, the least recently used one among equals.
+	lfu struct {
+		entries lfuHeap
+		clock   uint64
+	}
+
+	// lfuHeap is a min-heap of entries by frequency and last access.
+	lfuHeap []*item
+
+	// tinyLFU evicts in the LRU order and admits new entries by their estimated frequency.
+	tinyLFU struct {
+		lru
+		sketch *sketch
+	}
+
+	// custom adapts a local.Policy working with keys.
+	custom struct {
+		policy local.Policy
+		items  map[any]*item // entries of the store by key
+	}
+
+	// sketch is a count-min sketch of key frequencies with 4-bit counters and periodic aging.
+	sketch struct {
+		seed      maphash.Seed
+		rows      [sketchDepth][]uint8
+		mask      uint64
+		additions int
+		resetAt   int
+	}
+)
+
+const (
+	sketchDepth    = 4
+	sketchMaxCount = 15
+	sketchMinWidth = 1024
+)
+
+// newPolicy creates the policy of the eviction kind; width is the expected number of entries.
+func newPolicy(eviction local.Eviction, width int) policy {
+	switch eviction {
+	case local.EvictionLFU:
+		return &lfu{}
+	case local.EvictionTinyLFU:
+		return &tinyLFU{lru: lru{order: list.New()}, sketch: newSketch(width)}
+	default:
+		return &lru{order: list.New()}
+	}
+}
+
+func (a *lru) add(e *item)           { e.element = a.order.PushFront(e) }
+func (a *lru) access(e *item)        { a.order.MoveToFront(e.element) }
+func (a *lru) remove(e *item)        { a.order.Remove(e.element) }
+func (a *lru) admit(any, *item) bool { return true }
+func (a *lru) record(any)            {}
+func (a *lru) victim() *item {
+	if back := a.order.Back(); back != nil {
+		return back.Value.(*item)
+	}
+	return nil
+}
+
+func (a *lfu) add(e *item) {
+	a.clock++
+	e.hits, e.accessed = 1, a.clock
+	heap.Push(&a.entries, e)
+}
+func (a *lfu) access(e *item) {
+	a.clock++
+	e.hits, e.accessed = e.hits+1, a.clock
+	heap.Fix(&a.entries, e.index)
+}
+func (a *lfu) remove(e *item)        { heap.Remove(&a.entries, e.index) }
+func (a *lfu) admit(any, *item) bool { return true }
+func (a *lfu) record(any)            {}
+func (a *lfu) victim() *item {
+	if len(a.entries) == 0 {
+		return nil
+	}
+	return a.entries[0]
+}
+
+func (a lfuHeap) Len() int { return len(a) }
+func (a lfuHeap) Less(i, j int) bool {
+	if a[i].hits != a[j].hits {
+		return a[i].hits < a[j].hits
+	}
+	return a[i].accessed < a[j].accessed
+}
+func (a lfuHeap) Swap(i, j int) {
+	a[i], a[j] = a[j], a[i]
+	a[i].index, a[j].index = i, j
+}
+func (a *lfuHeap) Push(v any) {
+	e := v.(*item)
+	e.index = len(*a)
+	*a = append(*a, e)
+}
+func (a *lfuHeap) Pop() any {
+	old := *a
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*a = old[:len(old)-1]
+	return e
+}
+
+func (a *custom) add(e *item)                      { a.policy.Add(e.key) }
+func (a *custom) access(e *item)                   { a.policy.Access(e.key) }
+func (a *custom) remove(e *item)                   { a.policy.Remove(e.key) }
+func (a *custom) admit(key any, victim *item) bool { return a.policy.Admit(key, victim.key) }
+func (a *custom) record(key any)                   { a.policy.Record(key) }
+func (a *custom) victim() *item {
+	if key, ok := a.policy.Victim(); ok {
+		return a.items[key]
+	}
+	return nil
+}
+
+func (a *tinyLFU) admit(key any, victim *item) bool {
+	return a.sketch.estimate(key) > a.sketch.estimate(victim.key)
+}
+func (a *tinyLFU) record(key any) { a.sketch.increment(key) }
+
+// newSketch creates a sketch sized for the expected number of keys.
+func newSketch(width int) *sketch {
+	size := sketchMinWidth
+	for size < width {
+		size <<= 1
+	}
+	result := &sketch{
+		seed:    maphash.MakeSeed(),
+		mask:    uint64(size - 1),
+		resetAt: size * 10,
+	}
+	for i := range result.rows {
+		result.rows[i] = make([]uint8, size)
+	}
+	return result
+}
+
+// increment counts an occurrence of the key, halving all counters once enough occurrences were counted,
+// so that the estimates follow recent popularity.
+func (a *sketch) increment(key any) {
+	h := maphash.Comparable(a.seed, key)
+	for i := range a.rows {
+		if index := a.index(h, i); a.rows[i][index] < sketchMaxCount {
+			a.rows[i][index]++
+		}
+	}
+	a.additions++
+	if a.additions >= a.resetAt {
+		for i := range a.rows {
+			for j := range a.rows[i] {
+				a.rows[i][j] >>= 1
+			}
+		}
+		a.additions /= 2
+	}
+}
+
+// estimate returns the estimated number of occurrences of the key.
+func (a *sketch) estimate(key any) uint8 {
+	h := maphash.Comparable(a.seed, key)
+	result := uint8(sketchMaxCount)
+	for i := range a.rows {
+		result = min(result, a.rows[i][a.index(h, i)])
+	}
+	return result
+}
+
+// index returns the counter index of the hash in the row (double hashing).
+func (a *sketch) index(h uint64, row int) uint64 {
+	return (h + uint64(row)*(h>>32|1)) & a.mask
+}
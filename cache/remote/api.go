@@ -0,0 +1,74 @@
+package remote
+
+import (
+	"context"
+	"time"
+
+	"github.com/hypershadow-io/contract/cache"
+	"github.com/hypershadow-io/contract/codec"
+)
+
+type (
+	// Builder defines the interface for creating remote cache instances shared between nodes.
+	Builder interface {
+		// NewInstance creates a cache instance of the plugin whose values are serialized with the codec.
+		// Keys, tags and prefixes are namespaced per plugin and per organization of the context,
+		// so instances of different plugins and organizations never see each other's values.
+		// The plugin ID must not be empty or contain ':', the separator of the namespace.
+		NewInstance(pluginID string, codec codec.Client) (Instance, error)
+	}
+
+	// Instance defines a remote cache instance.
+	// Values are decoded into new values of the proto type, similar to db.Instance.FindOne.
+	Instance interface {
+		// Get retrieves a value by key and decodes it into a new value of the proto type.
+		// Returns the value, a boolean indicating whether it was found, and an error if occurred.
+		Get(c context.Context, errBuilder func() error, proto any, key string) (res_ any, found_ bool, err_ error)
+
+		// GetMany retrieves values by keys in a single round trip and decodes them into new values of the proto type.
+		// Returns found values by key; missing keys are absent.
+		GetMany(c context.Context, errBuilder func() error, proto any, keys ...string) (map[string]any, error)
+
+		// Set encodes the value and stores it under the key with optional configuration (TTL, tags).
+		Set(c context.Context, key string, value any, opts ...cache.SetOption) error
+
+		// SetMany encodes and stores values by key in a single round trip; options apply to every value.
+		SetMany(c context.Context, values map[string]any, opts ...cache.SetOption) error
+
+		// Delete removes values by keys.
+		Delete(c context.Context, keys ...string) error
+
+		// InvalidateTag removes all values tagged with any of the given tags.
+		InvalidateTag(c context.Context, tags ...string) error
+
+		// DeletePrefix removes all values whose keys start with the given prefix.
+		DeletePrefix(c context.Context, prefix string) error
+	}
+
+	// Server defines the byte-level storage of remote cache instances (e.g. an adapter of an external cache service).
+	// Keys and tags are already namespaced.
+	Server interface {
+		// Get returns stored values by keys; missing keys are absent.
+		Get(c context.Context, keys ...string) (map[string][]byte, error)
+
+		// Set stores the entries.
+		Set(c context.Context, entries ...Entry) error
+
+		// Delete removes values by keys.
+		Delete(c context.Context, keys ...string) error
+
+		// InvalidateTag removes all values tagged with any of the given tags.
+		InvalidateTag(c context.Context, tags ...string) error
+
+		// DeletePrefix removes all values whose keys start with the given prefix.
+		DeletePrefix(c context.Context, prefix string) error
+	}
+
+	// Entry is a serialized value stored by a Server.
+	Entry struct {
+		Key   string        // namespaced key
+		Value []byte        // serialized value
+		TTL   time.Duration // time-to-live; zero means the server default
+		Tags  []string      // namespaced invalidation tags
+	}
+)
@@ -0,0 +1,41 @@
+package remote
+
+import (
+	"context"
+)
+
+// Get retrieves a value of type T from the remote cache using the given key and error builder.
+// Returns the typed result, a boolean indicating whether it was found, and an error if occurred.
+func Get[T any](
+	c context.Context,
+	instance Instance,
+	errBuilder func() error,
+	proto T,
+	key string,
+) (res_ T, found_ bool, err_ error) {
+	result, found, err := instance.Get(c, errBuilder, proto, key)
+	if err != nil || !found {
+		return res_, found, err
+	}
+	return result.(T), found, nil
+}
+
+// GetMany retrieves values of type T from the remote cache in a single round trip.
+// Returns found values by key; missing keys are absent.
+func GetMany[T any](
+	c context.Context,
+	instance Instance,
+	errBuilder func() error,
+	proto T,
+	keys ...string,
+) (map[string]T, error) {
+	values, err := instance.GetMany(c, errBuilder, proto, keys...)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]T, len(values))
+	for key, value := range values {
+		result[key] = value.(T)
+	}
+	return result, nil
+}
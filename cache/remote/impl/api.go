@@ -0,0 +1,176 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hypershadow-io/contract/cache"
+	"github.com/hypershadow-io/contract/cache/remote"
+	"github.com/hypershadow-io/contract/codec"
+	"github.com/hypershadow-io/contract/eb"
+	orgctx "github.com/hypershadow-io/contract/organization/ctx"
+)
+
+// New returns the default remote.Builder serializing values with the codec of each instance
+// and namespacing keys before passing them to the server.
+//
+// Example (tests):
+//
+//	builder := impl.New(memory.New(), orgClient)
+func New(server remote.Server, orgClient orgctx.Client) remote.Builder {
+	return builder{server: server, orgClient: orgClient}
+}
+
+type (
+	// builder is the default remote.Builder implementation.
+	builder struct {
+		server    remote.Server
+		orgClient orgctx.Client
+	}
+
+	// instance is the default remote.Instance implementation.
+	instance struct {
+		pluginID  string
+		server    remote.Server
+		codec     codec.Client
+		orgClient orgctx.Client
+	}
+
+	// setOptions holds settings of a Set call.
+	setOptions struct {
+		ttl  time.Duration
+		tags []string
+	}
+)
+
+var (
+	// ErrNilProto is returned when values are requested without a proto to decode them into.
+	ErrNilProto = errors.New("cache: nil proto")
+
+	// ErrInvalidPluginID is returned when the plugin ID is empty or contains the namespace separator ':',
+	// which would let the namespaces of different plugins overlap.
+	ErrInvalidPluginID = errors.New("cache: invalid plugin ID")
+
+	// ErrEncode is returned, wrapping the codec error, when a value cannot be encoded.
+	ErrEncode = errors.New("cache: cannot encode value")
+)
+
+func (a builder) NewInstance(pluginID string, codec codec.Client) (remote.Instance, error) {
+	if pluginID == "" || strings.Contains(pluginID, ":") {
+		return nil, ErrInvalidPluginID
+	}
+	return &instance{pluginID: pluginID, server: a.server, codec: codec, orgClient: a.orgClient}, nil
+}
+
+func (a *instance) Get(c context.Context, errBuilder func() error, proto any, key string) (res_ any, found_ bool, err_ error) {
+	values, err := a.GetMany(c, errBuilder, proto, key)
+	if err != nil {
+		return nil, false, err
+	}
+	res_, found_ = values[key]
+	return res_, found_, nil
+}
+
+func (a *instance) GetMany(c context.Context, errBuilder func() error, proto any, keys ...string) (map[string]any, error) {
+	t := reflect.TypeOf(proto)
+	if t == nil {
+		return nil, wrap(errBuilder, ErrNilProto)
+	}
+	namespace := a.namespace(c)
+	namespaced := make([]string, len(keys))
+	for i, key := range keys {
+		namespaced[i] = namespace + key
+	}
+	data, err := a.server.Get(c, namespaced...)
+	if err != nil {
+		return nil, wrap(errBuilder, err)
+	}
+	result := make(map[string]any, len(data))
+	for i, key := range keys {
+		value, ok := data[namespaced[i]]
+		if !ok {
+			continue
+		}
+		target := reflect.New(t)
+		if err = a.codec.Unmarshal(value, target.Interface()); err != nil {
+			return nil, wrap(errBuilder, err)
+		}
+		result[key] = target.Elem().Interface()
+	}
+	return result, nil
+}
+
+func (a *instance) Set(c context.Context, key string, value any, opts ...cache.SetOption) error {
+	return a.SetMany(c, map[string]any{key: value}, opts...)
+}
+
+func (a *instance) SetMany(c context.Context, values map[string]any, opts ...cache.SetOption) error {
+	o := &setOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	namespace := a.namespace(c)
+	tags := make([]string, len(o.tags))
+	for i, tag := range o.tags {
+		tags[i] = namespace + tag
+	}
+	entries := make([]remote.Entry, 0, len(values))
+	for key, value := range values {
+		data, err := a.codec.Marshal(value)
+		if err != nil {
+			return wrap(errEncode, err)
+		}
+		entries = append(entries, remote.Entry{Key: namespace + key, Value: data, TTL: o.ttl, Tags: tags})
+	}
+	return a.server.Set(c, entries...)
+}
+
+func (a *instance) Delete(c context.Context, keys ...string) error {
+	namespace := a.namespace(c)
+	namespaced := make([]string, len(keys))
+	for i, key := range keys {
+		namespaced[i] = namespace + key
+	}
+	return a.server.Delete(c, namespaced...)
+}
+
+func (a *instance) InvalidateTag(c context.Context, tags ...string) error {
+	namespace := a.namespace(c)
+	namespaced := make([]string, len(tags))
+	for i, tag := range tags {
+		namespaced[i] = namespace + tag
+	}
+	return a.server.InvalidateTag(c, namespaced...)
+}
+
+func (a *instance) DeletePrefix(c context.Context, prefix string) error {
+	return a.server.DeletePrefix(c, a.namespace(c)+prefix)
+}
+
+// namespace returns the key prefix of the plugin and the organization of the context
+// (e.g. "integration:42:"); platform-level values use the organization ID 0.
+func (a *instance) namespace(c context.Context) string {
+	return a.pluginID + ":" + strconv.FormatInt(a.orgClient.IDFromContext(c), 10) + ":"
+}
+
+// wrap attaches the cause to the error built by errBuilder, if any.
+func wrap(errBuilder func() error, err error) error {
+	if errBuilder == nil {
+		return err
+	}
+	result := errBuilder()
+	if builder, ok := result.(eb.Builder); ok {
+		return builder.AddWrap(err)
+	}
+	return errors.Join(result, err)
+}
+
+// errEncode builds the error of values that cannot be encoded.
+func errEncode() error { return ErrEncode }
+
+func (a *setOptions) SetTTL(v time.Duration) { a.ttl = v }
+func (a *setOptions) SetTags(v ...string)    { a.tags = v }
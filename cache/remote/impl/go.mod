@@ -0,0 +1,16 @@
+module github.com/hypershadow-io/contract/cache/remote/impl
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/cache v1.2.0
+	github.com/hypershadow-io/contract/cache/remote v1.0.0
+	github.com/hypershadow-io/contract/codec v1.0.0
+	github.com/hypershadow-io/contract/eb v1.3.0
+	github.com/hypershadow-io/contract/organization/ctx v1.0.0
+)
+
+require (
+	github.com/hypershadow-io/contract/fielderror v1.1.0 // indirect
+	github.com/hypershadow-io/contract/meta v1.0.0 // indirect
+)
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/hypershadow-io/contract/cache"
+	"github.com/hypershadow-io/contract/cache/local/impl"
+	"github.com/hypershadow-io/contract/cache/remote"
+)
+
+// New returns an embedded in-memory remote.Server, a stand-in for an external cache service
+// in tests and single-node setups. Values are copied on the way in and out,
+// so callers cannot share memory with the stored values, just like with a real server.
+func New() remote.Server {
+	// unbounded, without a default TTL and without cleanup, which cannot fail
+	instance, _ := impl.New().NewInstance(0)
+	return &Server{instance: instance}
+}
+
+// Server is an in-memory remote cache server.
+type Server struct {
+	instance cache.Instance
+}
+
+func (a *Server) Get(c context.Context, keys ...string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		value, found, err := cache.Get[[]byte](c, a.instance, nil, key)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result[key] = bytes.Clone(value)
+		}
+	}
+	return result, nil
+}
+
+func (a *Server) Set(c context.Context, entries ...remote.Entry) error {
+	for _, entry := range entries {
+		opts := []cache.SetOption{cache.WithSetTags(entry.Tags...)}
+		if entry.TTL > 0 {
+			opts = append(opts, cache.WithSetTTL(entry.TTL))
+		}
+		if err := a.instance.Set(c, entry.Key, bytes.Clone(entry.Value), opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Server) Delete(c context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := a.instance.Delete(c, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Server) InvalidateTag(c context.Context, tags ...string) error {
+	return a.instance.InvalidateTag(c, tags...)
+}
+
+func (a *Server) DeletePrefix(c context.Context, prefix string) error {
+	return a.instance.DeletePrefix(c, prefix)
+}
@@ -0,0 +1,14 @@
+module github.com/hypershadow-io/contract/cache/remote/memory
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/cache v1.2.0
+	github.com/hypershadow-io/contract/cache/local/impl v1.0.0
+	github.com/hypershadow-io/contract/cache/remote v1.0.0
+)
+
+require (
+	github.com/hypershadow-io/contract/cache/local v1.1.0 // indirect
+	github.com/hypershadow-io/contract/codec v1.0.0 // indirect
+)
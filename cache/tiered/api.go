@@ -0,0 +1,231 @@
+package tiered
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hypershadow-io/contract/cache"
+	"github.com/hypershadow-io/contract/cache/bus"
+	"github.com/hypershadow-io/contract/cache/remote"
+	orgctx "github.com/hypershadow-io/contract/organization/ctx"
+)
+
+// New composes a local cache instance (L1) in front of a remote one (L2) into a single cache.Instance.
+// Reads are served from L1 and populate it from L2 on a miss (read-through); writes and invalidations
+// go to L2 first and then to L1 (write-through). Values are decoded from L2 into new values of the proto type.
+//
+// Without WithBus, only the L1 of the current node follows L2 changes; other nodes serve their L1 copies
+// until they expire, so keep the L1 TTL short.
+//
+// Values loaded by cache.GetOrLoad are stored as cache.LoadEntry[T], so such instances must use
+// the proto cache.LoadEntry[T]{} (e.g. cache.LoadEntry[*model.Agent]{}).
+//
+// Keys must be strings. Like L2, L1 keys, tags and prefixes are namespaced per organization of the context,
+// so organizations sharing the instance never read or invalidate each other's values.
+//
+// Example:
+//
+//	l1, _ := localBuilder.NewInstance(time.Minute, local.WithNewInstanceMaxEntries(10_000))
+//	l2, _ := remoteBuilder.NewInstance(pluginID, json.Client)
+//	instance := tiered.New(l1, l2, orgClient, &model.Agent{}, tiered.WithBus("agent.byid", invalidationBus))
+func New(l1 cache.Instance, l2 remote.Instance, orgClient orgctx.Client, proto any, opts ...Option) *Instance {
+	result := &Instance{l1: l1, l2: l2, orgClient: orgClient, proto: proto}
+	for _, opt := range opts {
+		opt(&result.options)
+	}
+	if result.options.bus != nil {
+		result.l1 = bus.Attach(result.options.busName, l1, result.options.bus)
+	}
+	return result
+}
+
+// WithL1TTL caps the TTL of values in L1 (the L1 instance default if zero).
+func WithL1TTL(v time.Duration) Option {
+	return func(o option) { o.SetL1TTL(v) }
+}
+
+// WithL2TTL sets the TTL of values in L2 set without an explicit TTL (the L2 server default if zero).
+func WithL2TTL(v time.Duration) Option {
+	return func(o option) { o.SetL2TTL(v) }
+}
+
+// WithBus broadcasts L1 invalidations to the instances of the same name on every node,
+// so that writes and invalidations on one node drop the L1 copies of all nodes.
+func WithBus(name string, b bus.Bus) Option {
+	return func(o option) { o.SetBus(name, b) }
+}
+
+type (
+	// Instance is a two-tier cache instance.
+	Instance struct {
+		l1        cache.Instance
+		l2        remote.Instance
+		orgClient orgctx.Client
+		proto     any
+		options   options
+	}
+
+	// Option defines a functional option for the two-tier instance.
+	Option func(option)
+
+	// option is an internal interface used to apply configuration.
+	option interface {
+		// SetL1TTL sets the TTL cap of L1 values.
+		SetL1TTL(time.Duration)
+
+		// SetL2TTL sets the default TTL of L2 values.
+		SetL2TTL(time.Duration)
+
+		// SetBus sets the invalidation bus of L1.
+		SetBus(name string, b bus.Bus)
+	}
+
+	// options holds settings of the two-tier instance.
+	options struct {
+		l1TTL   time.Duration
+		l2TTL   time.Duration
+		busName string
+		bus     bus.Bus
+	}
+
+	// setOptions captures the options of a Set call.
+	setOptions struct {
+		ttl  time.Duration
+		tags []string
+	}
+)
+
+// ErrKeyType is returned for keys which are not strings.
+var ErrKeyType = errors.New("cache: two-tier cache keys must be strings")
+
+// readThroughTag marks L1 copies populated from L2, whose own tags are unknown.
+const readThroughTag = "\x00tiered.readThrough"
+
+func (a *Instance) Get(c context.Context, errBuilder func() error, key any) (res_ any, found_ bool, err_ error) {
+	s, ok := key.(string)
+	if !ok {
+		return nil, false, ErrKeyType
+	}
+	namespace := a.namespace(c)
+	res_, found_, err_ = a.l1.Get(c, errBuilder, namespace+s)
+	if err_ != nil || found_ {
+		return res_, found_, err_
+	}
+	res_, found_, err_ = a.l2.Get(c, errBuilder, a.proto, s)
+	if err_ != nil || !found_ {
+		return res_, found_, err_
+	}
+	// L1 is a best-effort copy; failing to populate it must not fail the read
+	_ = a.l1.Set(c, namespace+s, res_, a.l1Options(namespace, &setOptions{tags: []string{readThroughTag}})...)
+	return res_, true, nil
+}
+
+// Set writes the value to L2 and L1. With a bus, L1 copies of the key on other nodes are dropped.
+func (a *Instance) Set(c context.Context, key any, value any, opts ...cache.SetOption) error {
+	s, ok := key.(string)
+	if !ok {
+		return ErrKeyType
+	}
+	o := &setOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	l2Options := []cache.SetOption{cache.WithSetTags(o.tags...)}
+	if ttl := firstPositive(o.ttl, a.options.l2TTL); ttl > 0 {
+		l2Options = append(l2Options, cache.WithSetTTL(ttl))
+	}
+	if err := a.l2.Set(c, s, value, l2Options...); err != nil {
+		return err
+	}
+	namespace := a.namespace(c)
+	if a.options.bus != nil {
+		if err := a.l1.Delete(c, namespace+s); err != nil {
+			return err
+		}
+	}
+	return a.l1.Set(c, namespace+s, value, a.l1Options(namespace, o)...)
+}
+
+func (a *Instance) Delete(c context.Context, key any) error {
+	s, ok := key.(string)
+	if !ok {
+		return ErrKeyType
+	}
+	if err := a.l2.Delete(c, s); err != nil {
+		return err
+	}
+	return a.l1.Delete(c, a.namespace(c)+s)
+}
+
+// InvalidateTag removes the tagged values from L2 and L1. Tags of values read through from L2 are unknown,
+// so all L1 copies of the organization populated by reads are dropped as well.
+func (a *Instance) InvalidateTag(c context.Context, tags ...string) error {
+	if err := a.l2.InvalidateTag(c, tags...); err != nil {
+		return err
+	}
+	namespace := a.namespace(c)
+	namespaced := make([]string, 0, len(tags)+1)
+	for _, tag := range tags {
+		namespaced = append(namespaced, namespace+tag)
+	}
+	return a.l1.InvalidateTag(c, append(namespaced, namespace+readThroughTag)...)
+}
+
+func (a *Instance) DeletePrefix(c context.Context, prefix string) error {
+	if err := a.l2.DeletePrefix(c, prefix); err != nil {
+		return err
+	}
+	return a.l1.DeletePrefix(c, a.namespace(c)+prefix)
+}
+
+// Close stops receiving L1 invalidations from other nodes, if a bus is configured.
+func (a *Instance) Close() {
+	if attached, ok := a.l1.(*bus.Instance); ok {
+		attached.Close()
+	}
+}
+
+// namespace returns the L1 key prefix of the organization of the context (e.g. "42:");
+// platform-level values use the organization ID 0, like L2.
+func (a *Instance) namespace(c context.Context) string {
+	return strconv.FormatInt(a.orgClient.IDFromContext(c), 10) + ":"
+}
+
+// l1Options returns the options of an L1 write: the requested TTL capped by the L1 TTL, and the namespaced tags.
+func (a *Instance) l1Options(namespace string, o *setOptions) []cache.SetOption {
+	tags := make([]string, len(o.tags))
+	for i, tag := range o.tags {
+		tags[i] = namespace + tag
+	}
+	result := []cache.SetOption{cache.WithSetTags(tags...)}
+	ttl := o.ttl
+	if a.options.l1TTL > 0 && (ttl <= 0 || ttl > a.options.l1TTL) {
+		ttl = a.options.l1TTL
+	}
+	if ttl > 0 {
+		result = append(result, cache.WithSetTTL(ttl))
+	}
+	return result
+}
+
+// firstPositive returns the first positive duration.
+func firstPositive(values ...time.Duration) time.Duration {
+	for _, v := range values {
+		if v > 0 {
+			return v
+		}
+	}
+	return 0
+}
+
+func (a *options) SetL1TTL(v time.Duration) { a.l1TTL = v }
+func (a *options) SetL2TTL(v time.Duration) { a.l2TTL = v }
+func (a *options) SetBus(name string, b bus.Bus) {
+	a.busName = name
+	a.bus = b
+}
+
+func (a *setOptions) SetTTL(v time.Duration) { a.ttl = v }
+func (a *setOptions) SetTags(v ...string)    { a.tags = v }
@@ -0,0 +1,118 @@
+package tiered
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/hypershadow-io/contract/cache"
+	"github.com/hypershadow-io/contract/cache/remote"
+)
+
+type (
+	// local is a minimal in-process L1 for tests.
+	local struct {
+		locker sync.Mutex
+		items  map[any]any
+	}
+
+	// server is a minimal L2 for tests; values are encoded as JSON and decoded into new values of the proto type.
+	server struct {
+		remote.Instance
+		locker sync.Mutex
+		items  map[string][]byte
+	}
+
+	// organizations places every context in the same organization.
+	organizations struct{}
+
+	// agent is a cached model.
+	agent struct {
+		Name string
+	}
+)
+
+func (a *local) Get(_ context.Context, _ func() error, key any) (any, bool, error) {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	value, ok := a.items[key]
+	return value, ok, nil
+}
+
+func (a *local) Set(_ context.Context, key any, value any, _ ...cache.SetOption) error {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	a.items[key] = value
+	return nil
+}
+
+func (a *local) Delete(_ context.Context, key any) error {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	delete(a.items, key)
+	return nil
+}
+
+func (a *local) InvalidateTag(context.Context, ...string) error { return nil }
+func (a *local) DeletePrefix(context.Context, string) error     { return nil }
+
+func (a *server) Get(_ context.Context, _ func() error, proto any, key string) (any, bool, error) {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	data, ok := a.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	target := reflect.New(reflect.TypeOf(proto))
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		return nil, false, err
+	}
+	return target.Elem().Interface(), true, nil
+}
+
+func (a *server) Set(_ context.Context, key string, value any, _ ...cache.SetOption) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	a.items[key] = data
+	return nil
+}
+
+func (organizations) IDFromContext(context.Context) int64                    { return 0 }
+func (organizations) IDToContext(c context.Context, _ int64) context.Context { return c }
+
+func TestInstance_GetOrLoad(t *testing.T) {
+	c := context.Background()
+	l2 := &server{items: make(map[string][]byte)}
+	calls := 0
+	loader := func(context.Context) (*agent, bool, error) {
+		calls++
+		return &agent{Name: "assistant"}, true, nil
+	}
+
+	instance := New(&local{items: make(map[any]any)}, l2, organizations{}, cache.LoadEntry[*agent]{})
+	if value, found, err := cache.GetOrLoad(c, instance, nil, "key", loader); err != nil || !found || value.Name != "assistant" {
+		t.Fatalf("GetOrLoad() = %+v, %v, %v, want the loaded value", value, found, err)
+	}
+
+	// another node (or an expired L1) reads the value through from L2
+	instance = New(&local{items: make(map[any]any)}, l2, organizations{}, cache.LoadEntry[*agent]{})
+	if value, found, err := cache.GetOrLoad(c, instance, nil, "key", loader); err != nil || !found || value.Name != "assistant" {
+		t.Fatalf("GetOrLoad() = %+v, %v, %v, want the value of L2", value, found, err)
+	}
+	if calls != 1 {
+		t.Errorf("loader calls = %d, want 1", calls)
+	}
+
+	// a proto of the plain type cannot hold the load entry
+	instance = New(&local{items: make(map[any]any)}, l2, organizations{}, &agent{})
+	if _, _, err := cache.GetOrLoad(c, instance, nil, "key", loader); !errors.Is(err, cache.ErrLoadEntryType) {
+		t.Errorf("GetOrLoad() error = %v, want %v", err, cache.ErrLoadEntryType)
+	}
+}
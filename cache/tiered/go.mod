@@ -0,0 +1,12 @@
+module github.com/hypershadow-io/contract/cache/tiered
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/cache v1.2.0
+	github.com/hypershadow-io/contract/cache/bus v1.0.0
+	github.com/hypershadow-io/contract/cache/remote v1.0.0
+	github.com/hypershadow-io/contract/organization/ctx v1.0.0
+)
+
+require github.com/hypershadow-io/contract/codec v1.0.0 // indirect
@@ -95,6 +95,27 @@ type (
 		) iter.Seq2[any, error]
 	}
 
+	// Listener is implemented by instances supporting asynchronous notifications (PostgreSQL LISTEN/NOTIFY).
+	Listener interface {
+		// Notify sends the payload to all listeners of the channel.
+		// If the context holds a transaction, the notification is delivered only after it is committed.
+		Notify(c context.Context, channel string, payload string) error
+
+		// Listen calls the handler with the payload of every notification sent to the channel.
+		// Blocks until the context is canceled or the connection is lost, and returns the cause.
+		// Notifications sent while no connection is listening are lost.
+		Listen(c context.Context, channel string, handler func(payload string), opts ...ListenOption) error
+	}
+
+	// ListenOption defines a function used to configure Listen behavior.
+	ListenOption func(listenOption)
+
+	// listenOption defines internal configuration methods for listen options.
+	listenOption interface {
+		// SetOnReady registers a callback invoked once the channel is being listened to.
+		SetOnReady(func())
+	}
+
 	// ExecResult represents the result of a write operation (INSERT/UPDATE/DELETE).
 	ExecResult interface {
 		// RowsAffected returns the number of rows modified by the operation.
@@ -114,3 +135,9 @@ type (
 		fs.ReadFileFS
 	}
 )
+
+// WithListenOnReady returns a ListenOption registering a callback invoked once the channel is being listened to,
+// e.g. to drop state that may be stale because of notifications lost before.
+func WithListenOnReady(v func()) ListenOption {
+	return func(opt listenOption) { opt.SetOnReady(v) }
+}
@@ -42,22 +42,42 @@ func Delete(
 	return run[qb.DeleteQuery](c, kinds, provider, value)
 }
 
+// Apply sequentially applies all registered mutators for the given query type and kinds
+// and reports the control action requested by the handler that stopped the chain (see hook.Signal).
+// If a mutator returns an error, it is attached to the result value using SetError.
+func Apply[T qb.SetError[T]](
+	c context.Context,
+	kinds hook.Kinds,
+	provider Provider[T],
+	value T,
+) hook.Result[T] {
+	result, err := hook.Mutate(c, kinds, provider, value)
+	if err != nil {
+		result.Value = result.Value.SetError(err)
+	}
+	return result
+}
+
 // run sequentially applies all registered mutators for the given query type and kinds.
 // If a mutator returns an error, it is attached to the result using SetError.
+// A replacement of the same query type is returned in place of the query. A skip, or a replacement
+// of another type (e.g. the UPDATE of a soft deletion), is attached as an error the same way,
+// so the query fails and the caller can detect the signal using hook.AsSignal.
+// Callers supporting vetoed or replaced operations must use Apply instead.
 func run[T qb.SetError[T]](
 	c context.Context,
 	kinds hook.Kinds,
 	provider Provider[T],
 	value T,
 ) T {
-	for handler := range provider.Find(c, kinds, value) {
-		patch, err := handler(c, kinds, value)
-		if err != nil {
-			return patch.SetError(err)
-		}
-		value = patch
+	result := Apply(c, kinds, provider, value)
+	if replacement, ok := result.Replacement.(T); ok && result.Action == hook.ActionReplace {
+		return replacement
+	}
+	if signal := result.Signal(); signal != nil {
+		return result.Value.SetError(signal)
 	}
-	return value
+	return result.Value
 }
 
 // Provider defines a hook provider for query mutators of type V.
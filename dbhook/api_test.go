@@ -0,0 +1,93 @@
+package dbhook_test
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/hypershadow-io/contract/dbhook"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/qb"
+)
+
+type (
+	// deleteQuery implements the part of qb.DeleteQuery used by the mutators.
+	deleteQuery struct {
+		qb.DeleteQuery
+		sql string
+		err error
+	}
+
+	// updateQuery implements the part of qb.UpdateQuery used by the mutators.
+	updateQuery struct {
+		qb.UpdateQuery
+		sql string
+	}
+
+	// handlers is a provider of a fixed list of mutators.
+	handlers[T any] []hook.MutatorFunc[T]
+)
+
+func (a deleteQuery) ToSql() (string, []any, error)     { return a.sql, nil, a.err }
+func (a deleteQuery) SetError(err error) qb.DeleteQuery { a.err = err; return a }
+func (a updateQuery) ToSql() (string, []any, error)     { return a.sql, nil, nil }
+
+func (a handlers[T]) Find(context.Context, hook.Kinds, T) iter.Seq[hook.MutatorFunc[T]] {
+	return slices.Values(a)
+}
+
+func TestDelete_Signals(t *testing.T) {
+	value := deleteQuery{sql: "DELETE FROM agents"}
+	tests := []struct {
+		name    string
+		handler hook.MutatorFunc[qb.DeleteQuery]
+		sql     string
+		action  hook.Action
+	}{
+		{
+			name: "none",
+			handler: func(_ context.Context, _ hook.Kinds, value qb.DeleteQuery) (qb.DeleteQuery, error) {
+				return value, nil
+			},
+			sql: "DELETE FROM agents",
+		},
+		{
+			name: "skip",
+			handler: func(_ context.Context, _ hook.Kinds, value qb.DeleteQuery) (qb.DeleteQuery, error) {
+				return value, hook.Skip()
+			},
+			action: hook.ActionSkip,
+		},
+		{
+			name: "replace with another query type",
+			handler: func(_ context.Context, _ hook.Kinds, value qb.DeleteQuery) (qb.DeleteQuery, error) {
+				return value, hook.Replace(updateQuery{sql: "UPDATE agents SET deleted_at = now()"})
+			},
+			action: hook.ActionReplace,
+		},
+		{
+			name: "replace with a delete",
+			handler: func(_ context.Context, _ hook.Kinds, value qb.DeleteQuery) (qb.DeleteQuery, error) {
+				return value, hook.Replace(deleteQuery{sql: "DELETE FROM archived_agents"})
+			},
+			sql: "DELETE FROM archived_agents",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := handlers[qb.DeleteQuery]{tt.handler}
+			sql, _, err := dbhook.Delete(context.Background(), hook.NewKinds(hook.KindDelete), provider, value).ToSql()
+			if tt.action != hook.ActionNone {
+				signal, ok := hook.AsSignal(err)
+				if !ok || signal.Action() != tt.action {
+					t.Fatalf("error = %v, want the signal of action %v", err, tt.action)
+				}
+				return
+			}
+			if err != nil || sql != tt.sql {
+				t.Errorf("got (%q, %v), want %q", sql, err, tt.sql)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package eb
 import (
 	"context"
 
+	"github.com/hypershadow-io/contract/fielderror"
 	"github.com/hypershadow-io/contract/meta"
 )
 
@@ -37,6 +38,16 @@ type (
 		// SetValidation sets field-level validation errors.
 		SetValidation(err map[string]string) Builder
 
+		// GetViolations returns structured validation errors with JSON-pointer paths,
+		// multiple codes per field and message parameters.
+		// If only legacy validation errors are set, they are converted (see fielderror.FromMap).
+		// If not explicitly set on the builder, attempts to retrieve it from wrapped errors.
+		GetViolations() fielderror.List
+
+		// SetViolations sets structured validation errors.
+		// GetValidation exposes them as the legacy map unless it is set explicitly.
+		SetViolations(list fielderror.List) Builder
+
 		// GetCode returns the optional numeric code (e.g., HTTP status).
 		// If not explicitly set on the builder, attempts to retrieve it from wrapped errors.
 		GetCode() int
@@ -79,6 +90,19 @@ type (
 		SetNoLogger() Builder
 	}
 
+	// StackGetter is implemented by builders capturing call sites (see eb/impl.EnableStack).
+	StackGetter interface {
+		// GetStack returns the captured frames: the creation call stack followed by the wrap call sites.
+		GetStack() []Frame
+	}
+
+	// Frame is a captured call site.
+	Frame struct {
+		Function string `json:"function"` // fully qualified function name
+		File     string `json:"file"`     // source file path
+		Line     int    `json:"line"`     // source line
+	}
+
 	// LazyBuilder is a deferred factory function that produces a new Builder instance.
 	LazyBuilder = func() Builder
 
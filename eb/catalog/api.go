@@ -0,0 +1,56 @@
+package catalog
+
+import (
+	"context"
+	"iter"
+
+	"github.com/hypershadow-io/contract/eb"
+)
+
+type (
+	// Client is a registry of error definitions shared by all plugins.
+	// Plugins register their definitions once (e.g. on plugin initialization)
+	// and create eb.Builder values from them instead of setting keys, codes and messages at every call site.
+	Client interface {
+		// Register adds error definitions owned by the given plugin.
+		// Returns an error if a definition has no key or its key is already registered by another plugin.
+		Register(pluginID string, list ...Definition) error
+
+		// Lookup returns the definition registered under the given key.
+		Lookup(key string) (res_ Definition, found_ bool)
+
+		// Definitions returns a sequence of all registered definitions with the IDs of their owner plugins.
+		Definitions() iter.Seq2[string, Definition]
+
+		// Make creates an error builder from the definition registered under the given key.
+		// The key, code and log message are taken from the definition, and the message is rendered
+		// for the locale from the context (see LocaleFromContext) using the given format arguments.
+		// An unknown key produces an error with the key set and the InternalServerError code.
+		Make(c context.Context, key string, args ...any) eb.Builder
+	}
+
+	// Definition describes a single logical error.
+	Definition struct {
+		Key      string            `json:"key"`           // stable machine-readable key (e.g. "agent.not_found")
+		Code     int               `json:"code"`          // default numeric code (e.g. HTTP status)
+		Messages map[string]string `json:"messages"`      // message format templates by locale (e.g. "en", "de-DE")
+		Log      string            `json:"log,omitempty"` // log message format template
+	}
+)
+
+// Message returns the message template for the first matching locale of the list.
+// A regional locale (e.g. "de-AT") falls back to its base language ("de").
+// Returns false if no locale matches.
+func (a Definition) Message(locales ...string) (string, bool) {
+	for _, locale := range locales {
+		if result, ok := a.Messages[locale]; ok {
+			return result, true
+		}
+		if base := baseLanguage(locale); base != locale {
+			if result, ok := a.Messages[base]; ok {
+				return result, true
+			}
+		}
+	}
+	return "", false
+}
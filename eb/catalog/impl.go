@@ -0,0 +1,132 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/hypershadow-io/contract/eb"
+	ebimpl "github.com/hypershadow-io/contract/eb/impl"
+	"github.com/hypershadow-io/contract/httpserver"
+)
+
+// DefaultLocale is the locale used when none of the requested locales has a message.
+const DefaultLocale = "en"
+
+// New creates an in-memory error catalog.
+func New(opts ...Option) Client {
+	o := &options{fallback: DefaultLocale}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &catalog{
+		options: *o,
+		items:   make(map[string]item),
+	}
+}
+
+// WithFallbackLocale sets the locale used when none of the requested locales has a message (DefaultLocale by default).
+func WithFallbackLocale(v string) Option {
+	return func(o option) { o.SetFallbackLocale(v) }
+}
+
+type (
+	// Option defines a functional option for the catalog.
+	Option func(option)
+
+	// option is an internal interface used to apply configuration.
+	option interface {
+		// SetFallbackLocale sets the fallback locale.
+		SetFallbackLocale(string)
+	}
+
+	// options holds catalog settings.
+	options struct {
+		fallback string
+	}
+
+	// catalog is the default Client implementation.
+	catalog struct {
+		options options
+		locker  sync.RWMutex
+		items   map[string]item // definitions by key
+		order   []string        // keys in registration order
+	}
+
+	// item is a registered definition with its owner.
+	item struct {
+		pluginID   string
+		definition Definition
+	}
+)
+
+func init() {
+	// errors built by the catalog are attributed to the package calling it
+	ebimpl.AddStackHelpers("github.com/hypershadow-io/contract/eb/catalog.")
+}
+
+func (a *catalog) Register(pluginID string, list ...Definition) error {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	for _, definition := range list {
+		if definition.Key == "" {
+			return errors.New("catalog: definition key is empty")
+		}
+		if current, ok := a.items[definition.Key]; ok && current.pluginID != pluginID {
+			return errors.New("catalog: key " + definition.Key + " is already registered by plugin " + current.pluginID)
+		}
+	}
+	for _, definition := range list {
+		if _, ok := a.items[definition.Key]; !ok {
+			a.order = append(a.order, definition.Key)
+		}
+		a.items[definition.Key] = item{pluginID: pluginID, definition: definition}
+	}
+	return nil
+}
+
+func (a *catalog) Lookup(key string) (res_ Definition, found_ bool) {
+	a.locker.RLock()
+	defer a.locker.RUnlock()
+	result, ok := a.items[key]
+	return result.definition, ok
+}
+
+func (a *catalog) Definitions() iter.Seq2[string, Definition] {
+	return func(yield func(string, Definition) bool) {
+		a.locker.RLock()
+		list := make([]item, 0, len(a.order))
+		for _, key := range a.order {
+			list = append(list, a.items[key])
+		}
+		a.locker.RUnlock()
+		for _, e := range list {
+			if !yield(e.pluginID, e.definition) {
+				return
+			}
+		}
+	}
+}
+
+func (a *catalog) Make(c context.Context, key string, args ...any) eb.Builder {
+	definition, ok := a.Lookup(key)
+	if !ok {
+		return ebimpl.Make[error]().
+			SetKey(key).
+			SetCode(httpserver.StatusInternalServerError)
+	}
+	var result eb.Builder = ebimpl.Make[error]().
+		SetKey(definition.Key).
+		SetCode(definition.Code)
+	if message, ok := definition.Message(append(slices.Clip(LocaleFromContext(c)), a.options.fallback)...); ok {
+		result = result.SetMessagef(message, args...)
+	}
+	if definition.Log != "" {
+		result = result.SetLogMessagef(definition.Log, args...)
+	}
+	return result
+}
+
+func (a *options) SetFallbackLocale(v string) { a.fallback = v }
@@ -0,0 +1,83 @@
+package catalog
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hypershadow-io/contract/httpserver"
+)
+
+// HeaderAcceptLanguage is the request header the locale preferences are read from.
+const HeaderAcceptLanguage = "Accept-Language"
+
+// LocaleToContext returns a new context carrying the preferred locales, most preferred first.
+func LocaleToContext(c context.Context, locales ...string) context.Context {
+	return context.WithValue(c, ctxKey{}, locales)
+}
+
+// LocaleFromContext returns the preferred locales stored in the context, most preferred first.
+func LocaleFromContext(c context.Context) []string {
+	result, _ := c.Value(ctxKey{}).([]string)
+	return result
+}
+
+// Middleware stores the locale preferences from the Accept-Language request header into the context.
+func Middleware(client httpserver.Client) httpserver.Handler {
+	return func(c context.Context) error {
+		ctx := client.CtxFromContext(c)
+		if locales := ParseAcceptLanguage(ctx.GetHeader(HeaderAcceptLanguage)); len(locales) > 0 {
+			c = LocaleToContext(c, locales...)
+		}
+		return ctx.Next(c)
+	}
+}
+
+// ParseAcceptLanguage parses the value of the Accept-Language header
+// into a list of locales ordered by quality, most preferred first.
+// The wildcard and zero-quality entries are skipped.
+func ParseAcceptLanguage(value string) []string {
+	type entry struct {
+		locale  string
+		quality float64
+	}
+	list := make([]entry, 0, 4)
+	for part := range strings.SplitSeq(value, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			v, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = v
+		}
+		if quality <= 0 {
+			continue
+		}
+		list = append(list, entry{locale: locale, quality: quality})
+	}
+	slices.SortStableFunc(list, func(x, y entry) int { return cmp.Compare(y.quality, x.quality) })
+	result := make([]string, 0, len(list))
+	for _, e := range list {
+		result = append(result, e.locale)
+	}
+	return result
+}
+
+// ctxKey is the context key of the locale preferences.
+type ctxKey struct{}
+
+// baseLanguage returns the language part of a regional locale (e.g. "de" for "de-AT").
+func baseLanguage(locale string) string {
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		return locale[:i]
+	}
+	return locale
+}
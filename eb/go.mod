@@ -2,4 +2,7 @@ module github.com/hypershadow-io/contract/eb
 
 go 1.24.0
 
-require github.com/hypershadow-io/contract/meta v1.0.0
+require (
+	github.com/hypershadow-io/contract/fielderror v1.1.0
+	github.com/hypershadow-io/contract/meta v1.0.0
+)
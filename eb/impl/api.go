@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/hypershadow-io/contract/eb"
+	"github.com/hypershadow-io/contract/fielderror"
 	"github.com/hypershadow-io/contract/fmt"
 	"github.com/hypershadow-io/contract/json"
 	"github.com/hypershadow-io/contract/meta"
@@ -15,7 +16,10 @@ import (
 
 // Make creates a new generic Builder instance for building structured errors of type E.
 // Initializes an empty wrap stack and prepares the builder for fluent-style chaining.
-func Make[E error]() Builder[E] { return Builder[E]{wrapped: make([]error, 0, 1)} }
+// If call site capture is enabled (see EnableStack), the call stack of Make is captured.
+func Make[E error]() Builder[E] {
+	return Builder[E]{wrapped: make([]error, 0, 1), stack: captureStack()}
+}
 
 // Wrap wraps the given base error into the provided error builder.
 // If the base error is already an eb.Builder, the new builder is added as a wrap to it.
@@ -33,11 +37,14 @@ type Builder[E error] struct {
 	message    string            // human-readable error message
 	key        string            // machine-readable error key for client/log correlation
 	validation map[string]string // field-level validation errors
+	violations fielderror.List   // structured validation errors
 	code       int               // numeric error code (e.g. HTTP status)
 	wrapped    []error           // additional errors to wrap
 	logMessage string            // optional log message (internal use)
 	meta       meta.Meta         // attached metadata for extended context
 	logger     eb.LogFunc        // optional logger for side-effect logging
+	stack      *stack            // optional captured call sites (see EnableStack)
+	remoteType string            // text of the base error type of a builder decoded by FromWire
 }
 
 var noLogger = func(context.Context, string, ...any) {}
@@ -82,6 +89,9 @@ func (a Builder[E]) GetValidation() map[string]string {
 	if a.validation != nil {
 		return a.validation
 	}
+	if a.violations != nil {
+		return a.violations.Map()
+	}
 	for e := range a.unwrap() {
 		if result := e.GetValidation(); result != nil {
 			return result
@@ -94,6 +104,25 @@ func (a Builder[E]) SetValidation(v map[string]string) eb.Builder {
 	return a
 }
 
+func (a Builder[E]) GetViolations() fielderror.List {
+	if a.violations != nil {
+		return a.violations
+	}
+	if a.validation != nil {
+		return fielderror.FromMap(a.validation)
+	}
+	for e := range a.unwrap() {
+		if result := e.GetViolations(); result != nil {
+			return result
+		}
+	}
+	return nil
+}
+func (a Builder[E]) SetViolations(v fielderror.List) eb.Builder {
+	a.violations = v
+	return a
+}
+
 func (a Builder[E]) GetCode() int {
 	if a.code != 0 {
 		return a.code
@@ -113,10 +142,13 @@ func (a Builder[E]) SetCode(v int) eb.Builder {
 func (a Builder[E]) AddWrap(v error) eb.Builder {
 	if v != nil {
 		a.wrapped = append(a.wrapped, v)
+		a.stack = a.stack.withWrapSite()
 	}
 	return a
 }
 
+// GetLogMessage returns the log message of the builder or of the first wrapped error that has one.
+// Captured stacks are not included; see GetStack.
 func (a Builder[E]) GetLogMessage() string {
 	if a.logMessage != "" {
 		return a.logMessage
@@ -187,6 +219,34 @@ func (a Builder[E]) SetNoLogger() eb.Builder {
 
 func (a Builder[E]) Unwrap() []error { return a.wrapped }
 
+// Is reports whether the builder matches the target, so that errors.Is works through the wrap chain:
+//   - an eb.Sentinel matches a builder with the same explicitly set key;
+//   - a value of the base error type E matches a builder created by Make[E].
+func (a Builder[E]) Is(target error) bool {
+	switch target := target.(type) {
+	case eb.Sentinel:
+		return a.key != "" && a.key == target.Key()
+	case E:
+		var base E
+		return any(base) != nil
+	}
+	return false
+}
+
+// As assigns the base error value of type E to the target if it is a *E,
+// so that errors.As can match builders created by Make[E] by their base type.
+func (a Builder[E]) As(target any) bool {
+	if result, ok := target.(*E); ok {
+		var base E
+		if any(base) == nil {
+			return false
+		}
+		*result = base
+		return true
+	}
+	return false
+}
+
 func (a Builder[E]) unwrap() iter.Seq[eb.Builder] {
 	return func(yield func(eb.Builder) bool) {
 		for i := range a.wrapped {
@@ -202,10 +262,9 @@ func (a Builder[E]) unwrap() iter.Seq[eb.Builder] {
 
 func (a Builder[E]) Error() string {
 	var builder strings.Builder
-	var base E
-	if any(base) != nil {
+	if baseType := a.baseType(); baseType != "" {
 		builder.WriteRune('<')
-		builder.WriteString(base.Error())
+		builder.WriteString(baseType)
 		builder.WriteRune('>')
 	}
 	if a.message != "" {
@@ -0,0 +1,72 @@
+package impl
+
+import (
+	"log/slog"
+	"strconv"
+
+	metaslog "github.com/hypershadow-io/contract/meta/slog"
+)
+
+// LogValue renders the builder as a structured slog group (implements slog.LogValuer),
+// so that an error passed to slog is logged with its type, key, code, messages, validation errors,
+// metadata, captured stack and the tree of wrapped errors instead of a single string.
+// Only the values set on this builder are rendered; values of wrapped builders appear in their own groups.
+func (a Builder[E]) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 10)
+	var base E
+	if any(base) != nil {
+		attrs = append(attrs, slog.String("type", base.Error()))
+	}
+	if a.key != "" {
+		attrs = append(attrs, slog.String("key", a.key))
+	}
+	if a.code != 0 {
+		attrs = append(attrs, slog.Int("code", a.code))
+	}
+	if a.message != "" {
+		attrs = append(attrs, slog.String("message", a.message))
+	}
+	if a.logMessage != "" {
+		attrs = append(attrs, slog.String("logMessage", a.logMessage))
+	}
+	if len(a.validation) > 0 {
+		list := make([]any, 0, len(a.validation))
+		for field, message := range a.validation {
+			list = append(list, slog.String(field, message))
+		}
+		attrs = append(attrs, slog.Group("validation", list...))
+	}
+	if len(a.violations) > 0 {
+		list := make([]any, 0, len(a.violations))
+		for i, item := range a.violations {
+			list = append(list, slog.Group(strconv.Itoa(i),
+				slog.String("path", item.Path),
+				slog.String("code", item.Code),
+				slog.String("message", item.Message),
+			))
+		}
+		attrs = append(attrs, slog.Group("violations", list...))
+	}
+	if !a.meta.IsZero() {
+		attrs = append(attrs, slog.Group("meta", metaslog.ToAttrs(a.meta)...))
+	}
+	if list := a.GetStack(); len(list) > 0 {
+		stack := make([]string, 0, len(list))
+		for _, frame := range list {
+			stack = append(stack, frame.Function+" ("+frame.File+":"+strconv.Itoa(frame.Line)+")")
+		}
+		attrs = append(attrs, slog.Any("stack", stack))
+	}
+	if len(a.wrapped) > 0 {
+		list := make([]any, 0, len(a.wrapped))
+		for i, err := range a.wrapped {
+			if _, ok := err.(slog.LogValuer); ok {
+				list = append(list, slog.Any(strconv.Itoa(i), err))
+			} else {
+				list = append(list, slog.String(strconv.Itoa(i), err.Error()))
+			}
+		}
+		attrs = append(attrs, slog.Group("wrapped", list...))
+	}
+	return slog.GroupValue(attrs...)
+}
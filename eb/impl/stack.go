@@ -0,0 +1,159 @@
+package impl
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hypershadow-io/contract/eb"
+)
+
+// EnableStack enables call site capture for all builders created by Make and for every AddWrap call.
+// Captured stacks are returned by GetStack and logged by LogValue; log messages do not include them.
+func EnableStack() { stackSettings.Store(&stackConfig{}) }
+
+// EnableStackFor enables call site capture only for builders created in packages
+// whose import path starts with one of the prefixes (e.g. the module path of a plugin).
+// Frames of helpers building errors on behalf of their callers (see AddStackHelpers)
+// are skipped, so the builder is attributed to the package calling the helper.
+func EnableStackFor(prefixes ...string) {
+	stackSettings.Store(&stackConfig{prefixes: prefixes})
+}
+
+// DisableStack disables call site capture (the default).
+func DisableStack() { stackSettings.Store(nil) }
+
+// AddStackHelpers registers function name prefixes of helpers creating or wrapping errors
+// on behalf of their callers (e.g. "example.com/plugin/errors.New").
+// Their frames are dropped from the beginning of captured stacks.
+// Packages register their own helpers in init (e.g. eb/catalog).
+func AddStackHelpers(prefixes ...string) {
+	stackHelpersLocker.Lock()
+	defer stackHelpersLocker.Unlock()
+	list := append(slices.Clone(*stackHelpers.Load()), prefixes...)
+	stackHelpers.Store(&list)
+}
+
+// StackDepth is the maximum number of frames captured by Make.
+const StackDepth = 16
+
+type (
+	// stackConfig holds call site capture settings.
+	stackConfig struct {
+		prefixes []string // package path prefixes; empty means all packages
+	}
+
+	// stack is a captured call stack with the call sites of AddWrap.
+	stack struct {
+		remote  []eb.Frame // frames captured by another process (see FromWire)
+		created []uintptr  // program counters of the Make call stack
+		wrapped []uintptr  // program counters of AddWrap call sites
+	}
+)
+
+var (
+	// stackSettings is nil while capture is disabled, keeping Make allocation-free.
+	stackSettings atomic.Pointer[stackConfig]
+
+	// stackHelpers holds function name prefixes of helper frames; replaced as a whole on registration.
+	stackHelpers       atomic.Pointer[[]string]
+	stackHelpersLocker sync.Mutex
+)
+
+func init() {
+	// frames of the panic machinery between a recovery and the panicking function
+	stackHelpers.Store(&[]string{"runtime."})
+}
+
+// GetStack returns the captured frames: the call stack of Make followed by the call sites of AddWrap.
+// Builders decoded by FromWire start with the frames captured by the remote side.
+// Returns nil if call site capture was disabled when the builder was created.
+func (a Builder[E]) GetStack() []eb.Frame {
+	if a.stack == nil {
+		return nil
+	}
+	result := append(slices.Clone(a.stack.remote), frames(a.stack.created)...)
+	return append(result, frames(a.stack.wrapped)...)
+}
+
+// captureStack captures the call stack of the caller of the function calling it, without leading helper frames.
+func captureStack() *stack {
+	config := stackSettings.Load()
+	if config == nil {
+		return nil
+	}
+	pcs := make([]uintptr, StackDepth)
+	pcs = skipHelpers(pcs[:runtime.Callers(3, pcs)])
+	if len(pcs) == 0 || !config.allows(pcs[0]) {
+		return nil
+	}
+	return &stack{created: pcs[:len(pcs):len(pcs)]}
+}
+
+// withWrapSite returns a copy of the stack with the call site of the caller of AddWrap appended.
+func (a *stack) withWrapSite() *stack {
+	config := stackSettings.Load()
+	if config == nil {
+		return a
+	}
+	var pcs [StackDepth]uintptr
+	site := skipHelpers(pcs[:runtime.Callers(3, pcs[:])])
+	if len(site) == 0 {
+		return a
+	}
+	if a == nil {
+		if !config.allows(site[0]) {
+			return nil
+		}
+		return &stack{wrapped: []uintptr{site[0]}}
+	}
+	return &stack{
+		remote:  a.remote,
+		created: a.created,
+		wrapped: append(a.wrapped[:len(a.wrapped):len(a.wrapped)], site[0]),
+	}
+}
+
+// skipHelpers drops the leading frames of registered helpers.
+func skipHelpers(pcs []uintptr) []uintptr {
+	helpers := *stackHelpers.Load()
+	for i := range pcs {
+		frame, _ := runtime.CallersFrames(pcs[i : i+1]).Next()
+		if !slices.ContainsFunc(helpers, func(prefix string) bool { return strings.HasPrefix(frame.Function, prefix) }) {
+			return pcs[i:]
+		}
+	}
+	return nil
+}
+
+// allows reports whether the function of the program counter belongs to an enabled package.
+func (a *stackConfig) allows(pc uintptr) bool {
+	if len(a.prefixes) == 0 {
+		return true
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	for _, prefix := range a.prefixes {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// frames resolves program counters into frames.
+func frames(pcs []uintptr) []eb.Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+	result := make([]eb.Frame, 0, len(pcs))
+	iterator := runtime.CallersFrames(pcs)
+	for {
+		frame, more := iterator.Next()
+		result = append(result, eb.Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		if !more {
+			return result
+		}
+	}
+}
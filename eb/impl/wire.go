@@ -0,0 +1,165 @@
+package impl
+
+import (
+	"errors"
+
+	"github.com/hypershadow-io/contract/eb"
+	"github.com/hypershadow-io/contract/fielderror"
+	"github.com/hypershadow-io/contract/json"
+	"github.com/hypershadow-io/contract/meta"
+)
+
+// Marshal encodes the error into JSON preserving the whole builder: type, message, key, code,
+// validation errors, metadata, log message, captured stack and the wrap chain.
+// Use it for errors crossing process boundaries (remote plugin calls, websocket messages).
+func Marshal(err error, opts ...WireOption) ([]byte, error) {
+	return json.Marshal(ToWire(err, opts...))
+}
+
+// Unmarshal decodes an error encoded by Marshal into an equivalent builder.
+func Unmarshal(data []byte) (eb.Builder, error) {
+	var result Wire
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return FromWire(result), nil
+}
+
+// ToWire converts the error into its transport representation.
+// Errors that are not eb/impl builders are represented by their text only.
+func ToWire(err error, opts ...WireOption) Wire {
+	o := &wireOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return toWire(err, o)
+}
+
+// FromWire rebuilds a builder from its transport representation.
+// The base error type cannot be restored, so the rebuilt builder has the ErrRemote base type;
+// the text of the original type and the captured stack are kept, and keys survive and can be matched
+// with eb.Sentinel. Loggers are never transported.
+func FromWire(w Wire) eb.Builder {
+	if w.Error != "" {
+		return Builder[ErrRemote]{wrapped: []error{errors.New(w.Error)}}
+	}
+	result := Builder[ErrRemote]{
+		message:    w.Message,
+		key:        w.Key,
+		validation: w.Validation,
+		violations: w.Violations,
+		code:       w.Code,
+		wrapped:    make([]error, 0, len(w.Wrapped)),
+		logMessage: w.LogMessage,
+		meta:       w.Meta,
+		remoteType: w.Type,
+	}
+	if len(w.Stack) > 0 {
+		result.stack = &stack{remote: w.Stack}
+	}
+	// the chain is rebuilt directly, AddWrap would record the call sites of FromWire
+	for _, item := range w.Wrapped {
+		if item.Error != "" {
+			result.wrapped = append(result.wrapped, errors.New(item.Error))
+			continue
+		}
+		result.wrapped = append(result.wrapped, FromWire(item))
+	}
+	return result
+}
+
+// WithWireRedactLog omits log messages and captured stacks from the encoding,
+// for transport to parties that must not see internal diagnostics.
+func WithWireRedactLog() WireOption {
+	return func(o wireOption) { o.SetRedactLog(true) }
+}
+
+type (
+	// Wire is the transport representation of an error builder.
+	Wire struct {
+		Type       string            `json:"type,omitempty"`       // text of the base error type
+		Error      string            `json:"error,omitempty"`      // text of a wrapped error which is not a builder
+		Message    string            `json:"message,omitempty"`    // human-readable message
+		Key        string            `json:"key,omitempty"`        // machine-readable key
+		Code       int               `json:"code,omitempty"`       // numeric code
+		Validation map[string]string `json:"validation,omitempty"` // field-level validation errors
+		Violations fielderror.List   `json:"violations,omitempty"` // structured validation errors
+		Meta       meta.Meta         `json:"meta,omitempty"`       // attached metadata
+		LogMessage string            `json:"logMessage,omitempty"` // log message, omitted when redacted
+		Stack      []eb.Frame        `json:"stack,omitempty"`      // captured call sites, omitted when redacted
+		Wrapped    []Wire            `json:"wrapped,omitempty"`    // wrap chain
+	}
+
+	// ErrRemote is the base error of builders decoded from the transport representation.
+	ErrRemote struct{}
+
+	// WireOption defines a functional option for the transport encoding.
+	WireOption func(wireOption)
+
+	// wireOption is an internal interface used to apply configuration.
+	wireOption interface {
+		// SetRedactLog enables omission of log messages and stacks.
+		SetRedactLog(bool)
+	}
+
+	// wireOptions holds transport encoding settings.
+	wireOptions struct {
+		redactLog bool
+	}
+
+	// wireEncoder is implemented by builders that can be converted into the transport representation.
+	wireEncoder interface {
+		toWire(o *wireOptions) Wire
+	}
+)
+
+func (ErrRemote) Error() string { return "remote" }
+
+// toWire converts the error using the given settings.
+func toWire(err error, o *wireOptions) Wire {
+	if err == nil {
+		return Wire{}
+	}
+	if encoder, ok := err.(wireEncoder); ok {
+		return encoder.toWire(o)
+	}
+	return Wire{Error: err.Error()}
+}
+
+// toWire converts the builder into its transport representation.
+func (a Builder[E]) toWire(o *wireOptions) Wire {
+	result := Wire{
+		Message:    a.message,
+		Key:        a.key,
+		Code:       a.code,
+		Validation: a.validation,
+		Violations: a.violations,
+		Meta:       a.meta,
+	}
+	result.Type = a.baseType()
+	if !o.redactLog {
+		result.LogMessage = a.logMessage
+		result.Stack = a.GetStack()
+	}
+	if len(a.wrapped) > 0 {
+		result.Wrapped = make([]Wire, 0, len(a.wrapped))
+		for _, err := range a.wrapped {
+			result.Wrapped = append(result.Wrapped, toWire(err, o))
+		}
+	}
+	return result
+}
+
+// baseType returns the text of the base error type, or of the original type of a builder decoded by FromWire.
+func (a Builder[E]) baseType() string {
+	if a.remoteType != "" {
+		return a.remoteType
+	}
+	var base E
+	if any(base) == nil {
+		return ""
+	}
+	return base.Error()
+}
+
+func (a *wireOptions) SetRedactLog(v bool) { a.redactLog = v }
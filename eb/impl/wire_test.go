@@ -0,0 +1,51 @@
+package impl
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hypershadow-io/contract/eb"
+)
+
+// errNotFound is a base error type for tests.
+type errNotFound struct{}
+
+func (errNotFound) Error() string { return "not found" }
+
+func TestWire_RoundTrip(t *testing.T) {
+	EnableStack()
+	defer DisableStack()
+	err := Make[errNotFound]().
+		SetKey("agent.notFound").
+		SetCode(404).
+		SetLogMessagef("agent 42").
+		AddWrap(Make[errNotFound]().SetMessagef("row")).
+		AddWrap(eb.Sentinel("plain"))
+
+	// the default client of the json package is set by the application, the transport is emulated
+	data, marshalErr := json.Marshal(ToWire(err))
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	var w Wire
+	if unmarshalErr := json.Unmarshal(data, &w); unmarshalErr != nil {
+		t.Fatal(unmarshalErr)
+	}
+	decoded := FromWire(w)
+
+	if got, want := decoded.Error(), err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	want := err.(Builder[errNotFound]).GetStack()
+	if len(want) == 0 {
+		t.Fatal("no stack was captured")
+	}
+	if got := decoded.(Builder[ErrRemote]).GetStack(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStack() = %v, want %v", got, want)
+	}
+	// the decoded builder encodes the same, without call sites of the decoding
+	if got, want := ToWire(decoded), ToWire(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToWire() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,199 @@
+package problem
+
+import (
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/hypershadow-io/contract/eb"
+	ebimpl "github.com/hypershadow-io/contract/eb/impl"
+	"github.com/hypershadow-io/contract/fielderror"
+	"github.com/hypershadow-io/contract/httpserver"
+	"github.com/hypershadow-io/contract/json"
+	"github.com/hypershadow-io/contract/meta"
+)
+
+// ContentType is the media type of problem details documents (RFC 9457).
+const ContentType = "application/problem+json"
+
+// FromError converts the error into problem details.
+// For an eb.Builder, the code, key, message, validation errors and the allowed metadata are exposed;
+// any other error is reported as an internal server error without details, to avoid leaking internals.
+func FromError(err error, opts ...Option) Problem {
+	o := newOption(opts)
+	var builder eb.Builder
+	if !errors.As(err, &builder) {
+		return Problem{
+			Type:   o.typeURI(""),
+			Title:  http.StatusText(httpserver.StatusInternalServerError),
+			Status: httpserver.StatusInternalServerError,
+		}
+	}
+	status := builder.GetCode()
+	if status < 400 || status > 599 {
+		status = httpserver.StatusInternalServerError
+	}
+	key := builder.GetKey()
+	result := Problem{
+		Type:       o.typeURI(key),
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     builder.GetMessage(),
+		Instance:   o.instance,
+		Key:        key,
+		Validation: builder.GetValidation(),
+		Violations: builder.GetViolations(),
+	}
+	if m := builder.GetMeta(); !m.IsZero() && len(o.metaKeys) > 0 {
+		for k, v := range m {
+			if o.metaKeys[k] {
+				if result.Meta == nil {
+					result.Meta = meta.Make(len(o.metaKeys))
+				}
+				result.Meta[k] = v
+			}
+		}
+	}
+	return result
+}
+
+// Render converts the error into problem details and encodes them as JSON.
+// Returns the HTTP status and the body to be sent with the ContentType header.
+func Render(err error, opts ...Option) (status_ int, body_ []byte, err_ error) {
+	result := FromError(err, opts...)
+	body, err := json.Marshal(result)
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.Status, body, nil
+}
+
+// Decode parses a problem details response back into an eb.Builder.
+// Returns false if the content type is not ContentType or the body is not a problem details document.
+func Decode(contentType string, body []byte) (eb.Builder, bool) {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	if !strings.EqualFold(strings.TrimSpace(mediaType), ContentType) {
+		return nil, false
+	}
+	var result Problem
+	if err := json.Unmarshal(body, &result); err != nil || result.Status == 0 {
+		return nil, false
+	}
+	return result.Builder(), true
+}
+
+// DecodeResponse reads a problem details response of an HTTP client into an eb.Builder.
+// Returns false if the response is not a problem details document; the body is consumed in any case.
+func DecodeResponse(res *http.Response) (eb.Builder, bool) {
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, false
+	}
+	return Decode(res.Header.Get(httpserver.HeaderContentType), body)
+}
+
+// WithTypeBase sets the base URI of problem types; the error key is appended to it
+// (e.g. "https://docs.example.com/errors/" + "agent.not_found").
+// Without it, the type is "about:blank".
+func WithTypeBase(v string) Option {
+	return func(o option) { o.SetTypeBase(v) }
+}
+
+// WithInstance sets the URI reference identifying the specific occurrence of the problem (e.g. the request URI).
+func WithInstance(v string) Option {
+	return func(o option) { o.SetInstance(v) }
+}
+
+// WithMetaKeys sets the metadata keys considered safe to expose to clients.
+// Metadata is not exposed by default.
+func WithMetaKeys(v ...string) Option {
+	return func(o option) { o.SetMetaKeys(v...) }
+}
+
+type (
+	// Problem is a problem details document (RFC 9457) extended with eb.Builder fields.
+	Problem struct {
+		Type       string            `json:"type"`                 // problem type URI
+		Title      string            `json:"title"`                // short summary of the problem type
+		Status     int               `json:"status"`               // HTTP status code
+		Detail     string            `json:"detail,omitempty"`     // human-readable explanation (eb.Builder message)
+		Instance   string            `json:"instance,omitempty"`   // URI reference of the occurrence
+		Key        string            `json:"key,omitempty"`        // machine-readable error key
+		Validation map[string]string `json:"validation,omitempty"` // field-level validation errors (legacy)
+		Violations fielderror.List   `json:"violations,omitempty"` // structured validation errors
+		Meta       meta.Meta         `json:"meta,omitempty"`       // client-safe metadata
+	}
+
+	// ErrProblem is the base error of builders decoded from problem details.
+	ErrProblem struct{}
+
+	// Option defines a functional option for problem details rendering.
+	Option func(option)
+
+	// option is an internal interface used to apply configuration.
+	option interface {
+		// SetTypeBase sets the base URI of problem types.
+		SetTypeBase(string)
+
+		// SetInstance sets the occurrence URI reference.
+		SetInstance(string)
+
+		// SetMetaKeys sets the client-safe metadata keys.
+		SetMetaKeys(...string)
+	}
+
+	// options holds rendering settings.
+	options struct {
+		typeBase string
+		instance string
+		metaKeys map[string]bool
+	}
+)
+
+func (ErrProblem) Error() string { return "problem" }
+
+// Builder converts the problem details into an eb.Builder.
+func (a Problem) Builder() eb.Builder {
+	var result eb.Builder = ebimpl.Make[ErrProblem]().
+		SetKey(a.Key).
+		SetCode(a.Status).
+		SetValidation(a.Validation).
+		SetViolations(a.Violations)
+	if a.Detail != "" {
+		result = result.SetMessagef(a.Detail)
+	} else {
+		result = result.SetMessagef(a.Title)
+	}
+	if !a.Meta.IsZero() {
+		result = result.SetMeta(maps.Clone(a.Meta))
+	}
+	return result
+}
+
+// newOption applies the options over the defaults.
+func newOption(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// typeURI returns the problem type URI for the error key.
+func (a *options) typeURI(key string) string {
+	if a.typeBase == "" || key == "" {
+		return "about:blank"
+	}
+	return a.typeBase + key
+}
+
+func (a *options) SetTypeBase(v string) { a.typeBase = v }
+func (a *options) SetInstance(v string) { a.instance = v }
+func (a *options) SetMetaKeys(v ...string) {
+	a.metaKeys = make(map[string]bool, len(v))
+	for _, key := range v {
+		a.metaKeys[key] = true
+	}
+}
@@ -0,0 +1,20 @@
+package eb
+
+// Sentinel is a comparable error identifying eb.Builder errors by their key.
+// errors.Is(err, sentinel) reports whether any builder in the wrap chain has the same key,
+// regardless of the wrap depth.
+//
+// Example:
+//
+//	var ErrNotFound = eb.Sentinel("agent.not_found")
+//	...
+//	return ebimpl.Make[ErrAgent]().SetKey(ErrNotFound.Key())
+//	...
+//	if errors.Is(err, ErrNotFound) { ... }
+type Sentinel string
+
+// Error returns the key of the sentinel.
+func (a Sentinel) Error() string { return string(a) }
+
+// Key returns the key of the sentinel.
+func (a Sentinel) Key() string { return string(a) }
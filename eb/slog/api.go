@@ -0,0 +1,87 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+
+	agentctx "github.com/hypershadow-io/contract/agent/ctx"
+	"github.com/hypershadow-io/contract/eb"
+	orgctx "github.com/hypershadow-io/contract/organization/ctx"
+)
+
+// Attribute keys added to records by the LogFunc adapter.
+const (
+	KeyOrganizationID = "organizationId"
+	KeyAgentID        = "agentId"
+)
+
+// LogFunc adapts the slog logger to eb.LogFunc.
+// Records are written with the configured level (slog.LevelError by default)
+// and enriched with the organization and agent IDs from the context, if the corresponding clients are set.
+// Errors passed as arguments are rendered as structured groups when they implement slog.LogValuer
+// (e.g. eb/impl builders).
+func LogFunc(logger *slog.Logger, opts ...Option) eb.LogFunc {
+	o := &options{level: slog.LevelError}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(c context.Context, msg string, args ...any) {
+		if !logger.Enabled(c, o.level) {
+			return
+		}
+		if o.orgClient != nil {
+			if organizationID := o.orgClient.IDFromContext(c); organizationID != 0 {
+				args = append(args, slog.Int64(KeyOrganizationID, organizationID))
+			}
+		}
+		if o.agentClient != nil {
+			if agentID := o.agentClient.IDFromContext(c); agentID != 0 {
+				args = append(args, slog.Int64(KeyAgentID, agentID))
+			}
+		}
+		logger.Log(c, o.level, msg, args...)
+	}
+}
+
+// WithLevel sets the level of the records.
+func WithLevel(v slog.Level) Option {
+	return func(o option) { o.SetLevel(v) }
+}
+
+// WithOrganization enables enrichment of the records with the organization ID from the context.
+func WithOrganization(v orgctx.Client) Option {
+	return func(o option) { o.SetOrganization(v) }
+}
+
+// WithAgent enables enrichment of the records with the agent ID from the context.
+func WithAgent(v agentctx.Client) Option {
+	return func(o option) { o.SetAgent(v) }
+}
+
+type (
+	// Option defines a functional option for the LogFunc adapter.
+	Option func(option)
+
+	// option is an internal interface used to apply configuration.
+	option interface {
+		// SetLevel sets the level of the records.
+		SetLevel(slog.Level)
+
+		// SetOrganization sets the organization context client.
+		SetOrganization(orgctx.Client)
+
+		// SetAgent sets the agent context client.
+		SetAgent(agentctx.Client)
+	}
+
+	// options holds adapter settings.
+	options struct {
+		level       slog.Level
+		orgClient   orgctx.Client
+		agentClient agentctx.Client
+	}
+)
+
+func (a *options) SetLevel(v slog.Level)           { a.level = v }
+func (a *options) SetOrganization(v orgctx.Client) { a.orgClient = v }
+func (a *options) SetAgent(v agentctx.Client)      { a.agentClient = v }
@@ -0,0 +1,14 @@
+module github.com/hypershadow-io/contract/eb/slog
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/agent/ctx v1.0.0
+	github.com/hypershadow-io/contract/eb v1.3.0
+	github.com/hypershadow-io/contract/organization/ctx v1.0.0
+)
+
+require (
+	github.com/hypershadow-io/contract/fielderror v1.1.0 // indirect
+	github.com/hypershadow-io/contract/meta v1.0.0 // indirect
+)
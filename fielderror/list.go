@@ -0,0 +1,153 @@
+package fielderror
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FromMap converts legacy field-level validation errors (field name -> message) into a List.
+// Field names in the dot/bracket notation (e.g. "attributes.properties[2].limit.min") are converted into JSON pointers.
+// Violations are ordered by field name, so the result is deterministic.
+func FromMap(m map[string]string) List {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(List, 0, len(m))
+	for _, field := range slices.Sorted(maps.Keys(m)) {
+		result = append(result, Violation{Path: PointerFromField(field), Message: m[field]})
+	}
+	return result
+}
+
+// Pointer builds a JSON pointer (RFC 6901) from path segments; integer segments denote array indices.
+//
+// Example:
+//
+//	Pointer("attributes", "properties", 2, "limit", "min") => "/attributes/properties/2/limit/min"
+func Pointer(segments ...any) string {
+	var builder strings.Builder
+	for _, segment := range segments {
+		builder.WriteRune('/')
+		switch v := segment.(type) {
+		case int:
+			builder.WriteString(strconv.Itoa(v))
+		case string:
+			builder.WriteString(pointerEscaper.Replace(v))
+		}
+	}
+	return builder.String()
+}
+
+// PointerFromField converts a field name in the dot/bracket notation into a JSON pointer.
+//
+// Example:
+//
+//	PointerFromField("attributes.properties[2].limit.min") => "/attributes/properties/2/limit/min"
+func PointerFromField(field string) string {
+	if field == "" || strings.HasPrefix(field, "/") {
+		return field
+	}
+	segments := make([]any, 0, 4)
+	for part := range strings.SplitSeq(field, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		if name != "" {
+			segments = append(segments, name)
+		}
+		for rest != "" {
+			var index string
+			index, rest, _ = strings.Cut(rest, "]")
+			if i, err := strconv.Atoi(index); err == nil {
+				segments = append(segments, i)
+			} else {
+				segments = append(segments, index)
+			}
+			rest = strings.TrimPrefix(rest, "[")
+		}
+	}
+	return Pointer(segments...)
+}
+
+// FieldFromPointer converts a JSON pointer into a field name in the dot/bracket notation.
+//
+// Example:
+//
+//	FieldFromPointer("/attributes/properties/2/limit/min") => "attributes.properties[2].limit.min"
+func FieldFromPointer(pointer string) string {
+	if !strings.HasPrefix(pointer, "/") {
+		return pointer
+	}
+	var builder strings.Builder
+	for segment := range strings.SplitSeq(pointer[1:], "/") {
+		segment = pointerUnescaper.Replace(segment)
+		if _, err := strconv.Atoi(segment); err == nil && builder.Len() > 0 {
+			builder.WriteRune('[')
+			builder.WriteString(segment)
+			builder.WriteRune(']')
+			continue
+		}
+		if builder.Len() > 0 {
+			builder.WriteRune('.')
+		}
+		builder.WriteString(segment)
+	}
+	return builder.String()
+}
+
+type (
+	// Violation is a single validation failure of a field.
+	// A field may have multiple violations with different codes.
+	Violation struct {
+		Path    string         `json:"path"`             // JSON pointer of the field (e.g. "/attributes/properties/2/limit/min")
+		Code    string         `json:"code,omitempty"`   // machine-readable violation code (e.g. "min", "required")
+		Message string         `json:"message"`          // default human-readable message
+		Params  map[string]any `json:"params,omitempty"` // parameters for localized message templates (e.g. {"min": 1})
+	}
+
+	// List is a collection of validation violations.
+	List []Violation
+)
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// GetField returns the field name in the dot/bracket notation (implements Error).
+func (a Violation) GetField() string { return FieldFromPointer(a.Path) }
+
+// GetMessage returns the default message (implements Error).
+func (a Violation) GetMessage() string { return a.Message }
+
+// Add returns the list with a new violation appended.
+func (a List) Add(path string, code string, message string, params map[string]any) List {
+	return append(a, Violation{Path: path, Code: code, Message: message, Params: params})
+}
+
+// ByPath returns all violations of the field with the given JSON pointer.
+func (a List) ByPath(path string) List {
+	var result List
+	for _, item := range a {
+		if item.Path == path {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Map converts the list into legacy field-level validation errors (field name -> message),
+// keeping the first message of each field.
+func (a List) Map() map[string]string {
+	if len(a) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(a))
+	for _, item := range a {
+		field := item.GetField()
+		if _, ok := result[field]; !ok {
+			result[field] = item.Message
+		}
+	}
+	return result
+}
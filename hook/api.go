@@ -26,7 +26,20 @@ type (
 	// The registry may contain multiple handlers that match different kinds or values.
 	Registry[H any, V any] interface {
 		// Add registers a new hook handler with an associated filter.
-		Add(filter Filter[V], hook H) Registry[H, V]
+		// Returns a Registration handle that can be used to unregister the handler
+		// or to chain further registrations in the same registry.
+		Add(filter Filter[V], hook H) Registration[H, V]
+	}
+
+	// Registration is a handle to a single hook handler added to a Registry.
+	// It embeds the Registry it was created from, so registrations can still be chained.
+	Registration[H any, V any] interface {
+		Registry[H, V]
+
+		// Remove unregisters the handler from the registry.
+		// Returns false if the handler has already been removed.
+		// Safe to call concurrently with lookups; calling it more than once has no effect.
+		Remove() bool
 	}
 
 	// Provider defines an interface for retrieving applicable hook handlers
@@ -0,0 +1,288 @@
+package expr
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/meta"
+)
+
+type (
+	// Expr is a serializable hook filter expression that can be stored in plugin configuration.
+	// All conditions set on a single node are combined using logical AND; an empty node matches everything.
+	//
+	// Example (UI-initiated Create of integrations with definition key "x"):
+	//
+	//	{"initiator": ["UI"], "kinds": ["Create"], "fields": {"definitionKey": {"eq": "x"}}}
+	Expr struct {
+		// Kinds matches if all listed kinds are present.
+		Kinds []hook.Kind `json:"kinds,omitempty"`
+
+		// AnyKinds matches if at least one of the listed kinds is present.
+		AnyKinds []hook.Kind `json:"anyKinds,omitempty"`
+
+		// ExcludeKinds matches if none of the listed kinds are present.
+		ExcludeKinds []hook.Kind `json:"excludeKinds,omitempty"`
+
+		// Initiator matches if the operation was initiated by one of the listed initiator kinds (e.g. UI, System).
+		Initiator []hook.Kind `json:"initiator,omitempty"`
+
+		// Fields matches entity fields by name (see Fields of the corresponding entity hook package).
+		Fields map[string]Condition `json:"fields,omitempty"`
+
+		// Meta matches values of the entity metadata by dot-separated path (e.g. "source.channel").
+		Meta map[string]Condition `json:"meta,omitempty"`
+
+		// And matches if all nested expressions match.
+		And []Expr `json:"and,omitempty"`
+
+		// Or matches if at least one nested expression matches.
+		Or []Expr `json:"or,omitempty"`
+
+		// Not matches if the nested expression does not match.
+		Not *Expr `json:"not,omitempty"`
+	}
+
+	// Condition describes a comparison of a single value.
+	// All operators set on a condition are combined using logical AND.
+	Condition struct {
+		Eq     any   `json:"eq,omitempty"`     // value equals
+		Ne     any   `json:"ne,omitempty"`     // value does not equal
+		In     []any `json:"in,omitempty"`     // value equals one of the listed values
+		NotIn  []any `json:"notIn,omitempty"`  // value equals none of the listed values
+		Exists *bool `json:"exists,omitempty"` // value is present (true) or absent (false)
+	}
+
+	// Fields maps field names available in expressions to value getters of the model type T.
+	Fields[T any] map[string]func(value T) any
+
+	// metaGetter is implemented by models exposing runtime metadata.
+	metaGetter interface {
+		GetMeta() meta.Meta
+	}
+)
+
+// Compile converts the expression into a hook.Filter for the model type T.
+// Returns an error if the expression references unknown fields or has an invalid condition.
+func Compile[T any](e Expr, fields Fields[T]) (hook.Filter[T], error) {
+	list := make([]hook.Filter[T], 0, 4)
+	if len(e.Kinds) > 0 {
+		list = append(list, hook.MatchAllKinds[T](e.Kinds...))
+	}
+	if len(e.AnyKinds) > 0 {
+		list = append(list, hook.MatchAnyKinds[T](e.AnyKinds...))
+	}
+	if len(e.ExcludeKinds) > 0 {
+		exclude := hook.MatchAnyKinds[T](e.ExcludeKinds...)
+		list = append(list, func(c context.Context, kinds hook.Kinds, value T) bool { return !exclude(c, kinds, value) })
+	}
+	if len(e.Initiator) > 0 {
+		list = append(list, hook.MatchInitiatorKinds[T](e.Initiator...))
+	}
+	for name, cond := range e.Fields {
+		getter, ok := fields[name]
+		if !ok {
+			return nil, errors.New("unknown field: " + name)
+		}
+		match, err := compileCondition(cond)
+		if err != nil {
+			return nil, errors.New("field " + name + ": " + err.Error())
+		}
+		list = append(list, func(_ context.Context, _ hook.Kinds, value T) bool { return match(getter(value), true) })
+	}
+	for path, cond := range e.Meta {
+		if path == "" {
+			return nil, errors.New("empty meta path")
+		}
+		match, err := compileCondition(cond)
+		if err != nil {
+			return nil, errors.New("meta " + path + ": " + err.Error())
+		}
+		keys := strings.Split(path, ".")
+		list = append(list, func(_ context.Context, _ hook.Kinds, value T) bool {
+			m, ok := any(value).(metaGetter)
+			if !ok || isNil(value) {
+				return match(nil, false)
+			}
+			return match(lookupMeta(m.GetMeta(), keys))
+		})
+	}
+	if len(e.And) > 0 {
+		and, err := compileList(e.And, fields)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, hook.AndFilters(and...))
+	}
+	if len(e.Or) > 0 {
+		or, err := compileList(e.Or, fields)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, hook.OrFilters(or...))
+	}
+	if e.Not != nil {
+		not, err := Compile(*e.Not, fields)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, func(c context.Context, kinds hook.Kinds, value T) bool { return !not(c, kinds, value) })
+	}
+	switch len(list) {
+	case 0:
+		return hook.MatchAny[T](), nil
+	case 1:
+		return list[0], nil
+	}
+	return hook.AndFilters(list...), nil
+}
+
+// Field adapts a model getter for Fields, returning nil instead of calling the getter on a nil model.
+//
+// Example:
+//
+//	var Fields = expr.Fields[model.Model]{"id": expr.Field(model.Model.GetID)}
+func Field[T any, V any](get func(value T) V) func(value T) any {
+	return func(value T) any {
+		if isNil(value) {
+			return nil
+		}
+		return get(value)
+	}
+}
+
+// compileList compiles each nested expression.
+func compileList[T any](list []Expr, fields Fields[T]) ([]hook.Filter[T], error) {
+	result := make([]hook.Filter[T], 0, len(list))
+	for _, e := range list {
+		filter, err := Compile(e, fields)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, filter)
+	}
+	return result, nil
+}
+
+// compileCondition converts the condition into a predicate over a value and its presence flag.
+func compileCondition(cond Condition) (func(value any, exists bool) bool, error) {
+	for _, v := range slices.Concat([]any{cond.Eq, cond.Ne}, cond.In, cond.NotIn) {
+		if v != nil && !reflect.TypeOf(normalize(v)).Comparable() {
+			return nil, errors.New("value is not comparable")
+		}
+	}
+	eq, ne := normalize(cond.Eq), normalize(cond.Ne)
+	in, notIn := normalizeList(cond.In), normalizeList(cond.NotIn)
+	return func(value any, exists bool) bool {
+		if cond.Exists != nil && *cond.Exists != exists {
+			return false
+		}
+		if !exists {
+			return cond.Eq == nil && cond.In == nil
+		}
+		value = normalize(value)
+		if value != nil && !reflect.TypeOf(value).Comparable() {
+			return cond.Eq == nil && cond.Ne == nil && cond.In == nil && cond.NotIn == nil
+		}
+		if eq != nil && value != eq {
+			return false
+		}
+		if ne != nil && value == ne {
+			return false
+		}
+		if in != nil && !contains(in, value) {
+			return false
+		}
+		if notIn != nil && contains(notIn, value) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// isNil reports whether the value is nil or a nil pointer, map, slice, func, channel, or interface.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// lookupMeta resolves a nested meta value by path keys.
+func lookupMeta(m meta.Meta, keys []string) (any, bool) {
+	var current any = m
+	for _, key := range keys {
+		var ok bool
+		switch v := current.(type) {
+		case meta.Meta:
+			current, ok = v[key]
+		case map[string]any:
+			current, ok = v[key]
+		}
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// normalize converts integers to int64, integral floats to int64 and other floats to float64,
+// and named basic types to their base type, so that values decoded from configuration
+// (e.g. 42.0 from JSON) compare equal to typed model fields without losing the precision of large IDs.
+func normalize(value any) any {
+	if value == nil {
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return int64(u)
+		}
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f)
+		}
+		return f
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	}
+	return value
+}
+
+// normalizeList normalizes every value of the list.
+func normalizeList(list []any) []any {
+	if list == nil {
+		return nil
+	}
+	result := make([]any, 0, len(list))
+	for _, v := range list {
+		result = append(result, normalize(v))
+	}
+	return result
+}
+
+// contains checks whether the normalized list contains the value.
+func contains(list []any, value any) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package expr_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/hook/expr"
+)
+
+type model interface{ GetID() int64 }
+
+type entity struct{ id int64 }
+
+func (a *entity) GetID() int64 { return a.id }
+
+var fields = expr.Fields[model]{"id": expr.Field(model.GetID)}
+
+func TestCompile_Numbers(t *testing.T) {
+	const big = int64(1)<<53 + 1 // not representable as float64
+	tests := []struct {
+		name string
+		cond expr.Condition
+		id   int64
+		want bool
+	}{
+		{"decoded float", expr.Condition{Eq: float64(42)}, 42, true},
+		{"untyped int", expr.Condition{Eq: 42}, 42, true},
+		{"big id", expr.Condition{Eq: big}, big, true},
+		{"big id neighbor", expr.Condition{Eq: big}, big - 1, false},
+		{"fraction", expr.Condition{Eq: 42.5}, 42, false},
+		{"in", expr.Condition{In: []any{uint8(1), float64(42)}}, 42, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := expr.Compile(expr.Expr{Fields: map[string]expr.Condition{"id": tt.cond}}, fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := filter(context.Background(), hook.Kinds{}, &entity{id: tt.id}); got != tt.want {
+				t.Errorf("filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompile_NilModel(t *testing.T) {
+	filter, err := expr.Compile(expr.Expr{
+		Fields: map[string]expr.Condition{"id": {Eq: 1}},
+		Meta:   map[string]expr.Condition{"source": {Eq: "x"}},
+	}, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []model{nil, (*entity)(nil)} {
+		if filter(context.Background(), hook.Kinds{}, value) {
+			t.Errorf("filter(%#v) matched", value)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+module github.com/hypershadow-io/contract/hook/expr
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/hook v1.1.0
+	github.com/hypershadow-io/contract/meta v1.0.0
+)
@@ -1,6 +1,9 @@
 package hook
 
-import "context"
+import (
+	"context"
+	"unsafe"
+)
 
 // AndFilters combines multiple filters using logical AND.
 // Returns true only if all filters return true.
@@ -35,29 +38,122 @@ func MatchAny[T any]() Filter[T] {
 
 // MatchKind returns a filter that matches only if the given kind is present.
 func MatchKind[T any](kind Kind) Filter[T] {
-	return func(_ context.Context, kinds Kinds, _ T) bool { return kinds.Has(kind) }
+	mask, masked := maskOf([]Kind{kind})
+	return func(c context.Context, kinds Kinds, _ T) bool {
+		if set, ok := matchedSet(c, kinds, masked); ok {
+			return set.Intersects(mask)
+		}
+		return kinds.Has(kind)
+	}
 }
 
 // ExcludeKind returns a filter that matches only if the given kind is NOT present.
 func ExcludeKind[T any](kind Kind) Filter[T] {
-	return func(_ context.Context, kinds Kinds, _ T) bool { return kinds.Not(kind) }
+	mask, masked := maskOf([]Kind{kind})
+	return func(c context.Context, kinds Kinds, _ T) bool {
+		if set, ok := matchedSet(c, kinds, masked); ok {
+			return !set.Intersects(mask)
+		}
+		return kinds.Not(kind)
+	}
 }
 
 // MatchAllKinds returns a filter that matches only if all specified kinds are present.
 func MatchAllKinds[T any](list ...Kind) Filter[T] {
-	return func(_ context.Context, kinds Kinds, _ T) bool { return kinds.HasAll(list...) }
+	mask, masked := maskOf(list)
+	return func(c context.Context, kinds Kinds, _ T) bool {
+		if set, ok := matchedSet(c, kinds, masked); ok {
+			return set.Contains(mask)
+		}
+		return kinds.HasAll(list...)
+	}
 }
 
 // MatchAnyKinds returns a filter that matches if at least one of the specified kinds is present.
 func MatchAnyKinds[T any](list ...Kind) Filter[T] {
-	return func(_ context.Context, kinds Kinds, _ T) bool { return kinds.HasAny(list...) }
+	mask, masked := maskOf(list)
+	return func(c context.Context, kinds Kinds, _ T) bool {
+		if set, ok := matchedSet(c, kinds, masked); ok {
+			return set.Intersects(mask)
+		}
+		return kinds.HasAny(list...)
+	}
 }
 
 // MatchOnlyKinds returns a filter that matches only if the kinds exactly match the provided list.
 func MatchOnlyKinds[T any](list ...Kind) Filter[T] {
-	return func(_ context.Context, kinds Kinds, _ T) bool { return kinds.HasOnly(list...) }
+	mask, masked := maskOf(list)
+	return func(c context.Context, kinds Kinds, _ T) bool {
+		if set, ok := matchedSet(c, kinds, masked); ok && set.complete {
+			return set.Equal(mask)
+		}
+		return kinds.HasOnly(list...)
+	}
+}
+
+// WithMatchedKinds returns a context carrying the kinds converted into a KindSet, so that the kind filters
+// of this package evaluated with the same kinds use their precomputed masks instead of map lookups.
+// Hook providers call it once per lookup, before evaluating the filters of all handlers.
+func WithMatchedKinds(c context.Context, kinds Kinds) context.Context {
+	return context.WithValue(c, matchedKindsKey{}, newMatchedKinds(kinds))
 }
 
 // Filter defines a predicate used to determine whether a hook should apply
 // based on the current context, hook kinds, and target value.
 type Filter[T any] func(c context.Context, kinds Kinds, value T) bool
+
+type (
+	// matchedKindsKey is the context key of the kinds being matched.
+	matchedKindsKey struct{}
+
+	// matchedKinds holds the kinds being matched along with their set.
+	matchedKinds struct {
+		KindSet
+		kinds    Kinds
+		complete bool // whether all kinds are registered and present, so that the set equals the kinds
+	}
+)
+
+// newMatchedKinds converts the kinds into a set; kinds missing in the registry are left out.
+func newMatchedKinds(kinds Kinds) *matchedKinds {
+	result := &matchedKinds{kinds: kinds, complete: true}
+	for item, ok := range kinds {
+		if !ok {
+			result.complete = false
+			continue
+		}
+		i, found := item.lookup()
+		if !found {
+			result.complete = false
+			continue
+		}
+		result.KindSet = result.set(i)
+	}
+	return result
+}
+
+// matchedSet returns the set of the kinds if the context carries it for the same kinds
+// and the filter mask is usable (masked).
+func matchedSet(c context.Context, kinds Kinds, masked bool) (*matchedKinds, bool) {
+	if !masked {
+		return nil, false
+	}
+	result, ok := c.Value(matchedKindsKey{}).(*matchedKinds)
+	if !ok || mapPointer(result.kinds) != mapPointer(kinds) {
+		return nil, false
+	}
+	return result, true
+}
+
+// mapPointer returns the identity of the kinds map (maps are pointers to the map header).
+func mapPointer(kinds Kinds) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&kinds))
+}
+
+// maskOf returns the set of the listed kinds; masked is false if any of them is not registered,
+// in which case filters fall back to map lookups. Filters do not register kinds,
+// since their lists may come from configuration (see hook/expr).
+func maskOf(list []Kind) (mask_ KindSet, masked_ bool) {
+	result, err := NewKindSet(list...)
+	return result, err == nil
+}
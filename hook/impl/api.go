@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/hook/trace"
 	"github.com/hypershadow-io/contract/plugin"
 )
 
@@ -42,8 +43,16 @@ type (
 		// Registry returns a plugin-scoped Registry for hook registration.
 		Registry(pluginID string) hook.Registry[H, V]
 
+		// RemovePlugin unregisters all hooks added by the given plugin
+		// (e.g. when the plugin is reconfigured or uninstalled at runtime).
+		// Returns the number of removed handlers.
+		RemovePlugin(pluginID string) int
+
 		// Provider retrieves applicable hooks for the given context, kinds, and value.
 		hook.Provider[H, V]
+
+		// FindWithPluginID works like Find, but also yields the ID of the plugin that registered each handler.
+		FindWithPluginID(c context.Context, kinds hook.Kinds, value V) iter.Seq2[string, H]
 	}
 
 	// collection is the internal implementation of a plugin-aware hook collection.
@@ -55,8 +64,9 @@ type (
 	// registry is the core hook storage mechanism.
 	// It stores hook handlers along with filters and associated plugin IDs.
 	registry[H any, V any] struct {
-		locker  sync.RWMutex  // protects concurrent access
-		storage []entry[H, V] // list of registered hook entries
+		locker   sync.RWMutex  // protects concurrent access
+		storage  []entry[H, V] // list of registered hook entries
+		sequence uint64        // last issued entry ID
 	}
 
 	// pluginRegistry is a wrapper that provides plugin-specific access to the shared registry.
@@ -65,8 +75,18 @@ type (
 		pluginID string          // ID of the owning plugin
 	}
 
+	// registration is a handle to a single entry that allows removing it from the shared registry.
+	registration[H any, V any] struct {
+		pluginRegistry[H, V]        // registry the entry was added to, used for chaining
+		id                   uint64 // ID of the registered entry
+	}
+
 	// entry represents a single hook registration.
 	entry[H any, V any] struct {
+		// id uniquely identifies the entry within the registry.
+		// Used to unregister a single handler through its registration handle.
+		id uint64
+
 		// pluginID identifies the plugin that registered this hook.
 		// Used for filtering and namespacing hook execution.
 		pluginID string
@@ -88,31 +108,88 @@ func (a *collection[H, V]) Registry(pluginID string) hook.Registry[H, V] {
 	}
 }
 
+func (a *collection[H, V]) RemovePlugin(pluginID string) int {
+	return a.registry.remove(func(e entry[H, V]) bool { return e.pluginID == pluginID })
+}
+
 func (a pluginRegistry[H, V]) Add(
 	filter hook.Filter[V],
 	hook H,
-) hook.Registry[H, V] {
+) hook.Registration[H, V] {
 	a.registry.locker.Lock()
+	a.registry.sequence++
+	id := a.registry.sequence
 	a.registry.storage = append(a.registry.storage, entry[H, V]{
+		id:       id,
 		pluginID: a.pluginID,
 		filter:   filter,
 		handler:  hook,
 	})
 	a.registry.locker.Unlock()
-	return a
+	return registration[H, V]{
+		pluginRegistry: a,
+		id:             id,
+	}
+}
+
+func (a registration[H, V]) Remove() bool {
+	return a.registry.remove(func(e entry[H, V]) bool { return e.id == a.id }) > 0
+}
+
+// remove deletes all entries matching the predicate and returns the number of removed entries.
+func (a *registry[H, V]) remove(match func(e entry[H, V]) bool) int {
+	a.locker.Lock()
+	count := len(a.storage)
+	a.storage = slices.DeleteFunc(a.storage, match)
+	count -= len(a.storage)
+	a.locker.Unlock()
+	return count
 }
 
 func (a *collection[H, V]) Find(c context.Context, kinds hook.Kinds, value V) iter.Seq[H] {
+	matched := a.find(c, kinds, value)
+	result := make([]H, 0, len(matched))
+	for _, h := range matched {
+		result = append(result, h.handler)
+	}
+	return slices.Values(result)
+}
+
+func (a *collection[H, V]) FindWithPluginID(c context.Context, kinds hook.Kinds, value V) iter.Seq2[string, H] {
+	matched := a.find(c, kinds, value)
+	return func(yield func(string, H) bool) {
+		for _, h := range matched {
+			if !yield(h.pluginID, h.handler) {
+				return
+			}
+		}
+	}
+}
+
+// find returns a snapshot of entries from active plugins whose filters match the given kinds and value.
+// If a trace collector is present in the context, every filter decision is recorded
+// and the matched handlers are wrapped to record their execution.
+func (a *collection[H, V]) find(c context.Context, kinds hook.Kinds, value V) []entry[H, V] {
+	collector := trace.FromContext(c)
 	a.registry.locker.RLock()
-	result := make([]H, 0, len(a.registry.storage))
+	cFilter := c
+	if len(a.registry.storage) > 0 {
+		// the kinds are converted once for the kind filters of all handlers
+		cFilter = hook.WithMatchedKinds(c, kinds)
+	}
+	result := make([]entry[H, V], 0, len(a.registry.storage))
 	for _, h := range a.registry.storage {
 		if !a.pc.IsActive(c, h.pluginID) {
 			continue
 		}
-		if h.filter == nil || h.filter(c, kinds, value) {
-			result = append(result, h.handler)
+		matched := h.filter == nil || h.filter(cFilter, kinds, value)
+		if collector != nil {
+			h.handler = traceHandler(collector, h, kinds, matched)
+		}
+		if matched {
+			result = append(result, h)
 		}
 	}
 	a.registry.locker.RUnlock()
-	return slices.Values(result)
+	return result
 }
@@ -0,0 +1,373 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	tokenctx "github.com/hypershadow-io/contract/auth/token/ctx"
+	"github.com/hypershadow-io/contract/hook"
+	orgctx "github.com/hypershadow-io/contract/organization/ctx"
+	"github.com/hypershadow-io/contract/runner"
+)
+
+// NewAsyncEvents creates a dispatcher that delivers event hooks from the given collection asynchronously.
+// Every plugin gets its own bounded worker pool, so a slow handler of one plugin
+// does not delay the caller or the handlers of other plugins.
+//
+// The dispatcher must be registered in the runner (or run manually) and is stopped via Dispose,
+// which waits until all queued events are delivered.
+func NewAsyncEvents[T any](events Events[T], opts ...AsyncOption) (AsyncEvents[T], error) {
+	cfg := asyncConfig{
+		workers:   1,
+		queueSize: 100,
+		policy:    AsyncPolicyBlock,
+		plugins:   make(map[string]asyncPoolConfig),
+		onError:   func(context.Context, string, error) {},
+	}
+	for _, opt := range opts {
+		if err := opt(&cfg); err != nil {
+			return nil, err
+		}
+	}
+	return &asyncEvents[T]{
+		events: events,
+		cfg:    cfg,
+		pools:  make(map[string]*asyncPool[T]),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// CopyOrganization returns a ContextCopier that transfers the organization ID into the detached context.
+func CopyOrganization(client orgctx.Client) ContextCopier {
+	return func(dst, src context.Context) context.Context {
+		return client.IDToContext(dst, client.IDFromContext(src))
+	}
+}
+
+// CopyToken returns a ContextCopier that transfers the auth token into the detached context.
+func CopyToken(client tokenctx.Client) ContextCopier {
+	return func(dst, src context.Context) context.Context {
+		if token := client.TokenFromContext(src); token != nil {
+			return client.TokenToContext(dst, token)
+		}
+		return dst
+	}
+}
+
+// WithAsyncWorkers sets the default number of workers started for each plugin.
+func WithAsyncWorkers(v int) AsyncOption {
+	return func(opt asyncOption) error { return opt.SetWorkers(v) }
+}
+
+// WithAsyncQueueSize sets the default capacity of the event queue of each plugin.
+func WithAsyncQueueSize(v int) AsyncOption {
+	return func(opt asyncOption) error { return opt.SetQueueSize(v) }
+}
+
+// WithAsyncPlugin overrides the number of workers and the queue capacity for the given plugin.
+func WithAsyncPlugin(pluginID string, workers int, queueSize int) AsyncOption {
+	return func(opt asyncOption) error { return opt.SetPlugin(pluginID, workers, queueSize) }
+}
+
+// WithAsyncPolicy sets the behavior used when the queue of a plugin is full.
+func WithAsyncPolicy(v AsyncPolicy) AsyncOption {
+	return func(opt asyncOption) error { return opt.SetPolicy(v) }
+}
+
+// WithAsyncContext sets the copiers used to build the detached context passed to the handlers.
+// If no copiers are set, the handlers receive the original context values without its cancellation.
+func WithAsyncContext(list ...ContextCopier) AsyncOption {
+	return func(opt asyncOption) error { return opt.SetContextCopiers(list...) }
+}
+
+// WithAsyncOnError registers a callback invoked when a handler fails, panics, or its event is dropped.
+func WithAsyncOnError(v func(c context.Context, pluginID string, err error)) AsyncOption {
+	return func(opt asyncOption) error { return opt.SetOnError(v) }
+}
+
+// Backpressure policies applied when the queue of a plugin is full.
+const (
+	// AsyncPolicyBlock blocks Dispatch until the queue has free space, the context is done, or the dispatcher is disposed.
+	AsyncPolicyBlock AsyncPolicy = iota
+
+	// AsyncPolicyDrop drops the event and reports ErrAsyncQueueFull to the error callback.
+	AsyncPolicyDrop
+
+	// AsyncPolicyError rejects the event and returns ErrAsyncQueueFull from Dispatch.
+	AsyncPolicyError
+)
+
+var (
+	// ErrAsyncQueueFull is reported when an event cannot be queued because the plugin's queue is full.
+	ErrAsyncQueueFull = errors.New("hook: async event queue is full")
+
+	// ErrAsyncClosed is returned by Dispatch after the dispatcher has been disposed.
+	ErrAsyncClosed = errors.New("hook: async event dispatcher is closed")
+)
+
+type (
+	// AsyncEvents delivers event hooks in background workers instead of the caller's goroutine.
+	AsyncEvents[T any] interface {
+		// Dispatch queues all handlers matching the given context, kinds, and value.
+		// Handlers receive a detached context, so they are not cancelled when the request is finished.
+		// Returns an error only if the event could not be queued according to the backpressure policy.
+		Dispatch(c context.Context, kinds hook.Kinds, value T) error
+
+		// Command allows the dispatcher to be managed by the runner.
+		// Run blocks until Dispose is called; Dispose stops accepting events and waits for queued ones.
+		runner.Command
+	}
+
+	// AsyncPolicy defines what happens when an event is dispatched to a plugin whose queue is full.
+	AsyncPolicy int
+
+	// ContextCopier transfers values from the source (request) context into the detached destination context.
+	ContextCopier func(dst, src context.Context) context.Context
+
+	// AsyncOption represents a functional option used to configure an async dispatcher during creation.
+	AsyncOption func(asyncOption) error
+
+	// asyncOption defines methods for configuring an async dispatcher.
+	asyncOption interface {
+		// SetWorkers sets the default number of workers per plugin.
+		SetWorkers(int) error
+
+		// SetQueueSize sets the default queue capacity per plugin.
+		SetQueueSize(int) error
+
+		// SetPlugin overrides the number of workers and the queue capacity for a specific plugin.
+		SetPlugin(pluginID string, workers int, queueSize int) error
+
+		// SetPolicy sets the backpressure policy.
+		SetPolicy(AsyncPolicy) error
+
+		// SetContextCopiers sets the copiers used to build the detached handler context.
+		SetContextCopiers(...ContextCopier) error
+
+		// SetOnError sets the callback for failed, panicked, or dropped events.
+		SetOnError(func(c context.Context, pluginID string, err error)) error
+	}
+
+	// asyncConfig is the internal implementation of asyncOption.
+	asyncConfig struct {
+		workers   int
+		queueSize int
+		policy    AsyncPolicy
+		plugins   map[string]asyncPoolConfig // per-plugin overrides
+		copiers   []ContextCopier
+		onError   func(c context.Context, pluginID string, err error)
+	}
+
+	// asyncPoolConfig holds the pool size settings of a single plugin.
+	asyncPoolConfig struct {
+		workers   int
+		queueSize int
+	}
+
+	// asyncEvents is the internal implementation of AsyncEvents.
+	asyncEvents[T any] struct {
+		events Events[T]
+		cfg    asyncConfig
+
+		locker sync.RWMutex             // guards closed flag and queue sending against Dispose
+		closed bool                     // set once Dispose is called
+		pools  map[string]*asyncPool[T] // lazily created worker pools by plugin ID
+		poolMu sync.Mutex               // protects pools creation
+		wg     sync.WaitGroup           // tracks running workers
+		stop   chan struct{}            // closed when Dispose is called, releases dispatchers blocked on full queues
+		once   sync.Once                // guards closing of stop
+		done   chan struct{}            // closed after all workers are stopped
+	}
+
+	// asyncPool is a bounded queue of a single plugin served by a fixed number of workers.
+	asyncPool[T any] struct {
+		queue chan asyncTask[T]
+	}
+
+	// asyncTask is a single handler invocation waiting in the queue.
+	asyncTask[T any] struct {
+		c        context.Context
+		kinds    hook.Kinds
+		value    T
+		pluginID string
+		handler  hook.EventFunc[T]
+	}
+)
+
+func (a *asyncEvents[T]) Dispatch(c context.Context, kinds hook.Kinds, value T) error {
+	a.locker.RLock()
+	defer a.locker.RUnlock()
+	if a.closed {
+		return ErrAsyncClosed
+	}
+	var (
+		detached context.Context
+		errs     []error
+	)
+	for pluginID, handler := range a.events.FindWithPluginID(c, kinds, value) {
+		if detached == nil {
+			detached = a.detach(c)
+			kinds = kinds.With()
+		}
+		task := asyncTask[T]{
+			c:        detached,
+			kinds:    kinds,
+			value:    value,
+			pluginID: pluginID,
+			handler:  handler,
+		}
+		if err := a.enqueue(c, a.pool(pluginID), task); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (a *asyncEvents[T]) Run() error {
+	<-a.done
+	return nil
+}
+
+func (a *asyncEvents[T]) Dispose() {
+	// dispatchers blocked on a full queue hold the read lock, so they are released before taking the write lock
+	a.once.Do(func() { close(a.stop) })
+	a.locker.Lock()
+	if a.closed {
+		a.locker.Unlock()
+		return
+	}
+	a.closed = true
+	a.poolMu.Lock()
+	for _, pool := range a.pools {
+		close(pool.queue)
+	}
+	a.poolMu.Unlock()
+	a.locker.Unlock()
+	a.wg.Wait()
+	close(a.done)
+}
+
+// detach builds the context passed to the handlers.
+func (a *asyncEvents[T]) detach(c context.Context) context.Context {
+	if len(a.cfg.copiers) == 0 {
+		return context.WithoutCancel(c)
+	}
+	result := context.Background()
+	for _, cp := range a.cfg.copiers {
+		result = cp(result, c)
+	}
+	return result
+}
+
+// enqueue puts the task into the pool queue according to the backpressure policy.
+func (a *asyncEvents[T]) enqueue(c context.Context, pool *asyncPool[T], task asyncTask[T]) error {
+	switch a.cfg.policy {
+	case AsyncPolicyDrop:
+		select {
+		case pool.queue <- task:
+		default:
+			a.cfg.onError(task.c, task.pluginID, ErrAsyncQueueFull)
+		}
+		return nil
+	case AsyncPolicyError:
+		select {
+		case pool.queue <- task:
+			return nil
+		default:
+			return ErrAsyncQueueFull
+		}
+	default:
+		select {
+		case pool.queue <- task:
+			return nil
+		case <-c.Done():
+			return c.Err()
+		case <-a.stop:
+			return ErrAsyncClosed
+		}
+	}
+}
+
+// pool returns the worker pool of the given plugin, starting it on first use.
+func (a *asyncEvents[T]) pool(pluginID string) *asyncPool[T] {
+	a.poolMu.Lock()
+	defer a.poolMu.Unlock()
+	if pool, ok := a.pools[pluginID]; ok {
+		return pool
+	}
+	cfg, ok := a.cfg.plugins[pluginID]
+	if !ok {
+		cfg = asyncPoolConfig{workers: a.cfg.workers, queueSize: a.cfg.queueSize}
+	}
+	pool := &asyncPool[T]{queue: make(chan asyncTask[T], cfg.queueSize)}
+	a.pools[pluginID] = pool
+	a.wg.Add(cfg.workers)
+	for range cfg.workers {
+		go a.work(pool)
+	}
+	return pool
+}
+
+// work delivers queued tasks until the queue is closed.
+func (a *asyncEvents[T]) work(pool *asyncPool[T]) {
+	defer a.wg.Done()
+	for task := range pool.queue {
+		err := callRecover(task.c, task.pluginID, func(c context.Context) error {
+			return task.handler(c, task.kinds, task.value)
+		})
+		if err != nil {
+			a.cfg.onError(task.c, task.pluginID, err)
+		}
+	}
+}
+
+func (a *asyncConfig) SetWorkers(v int) error {
+	if v <= 0 {
+		return errors.New("invalid workers: must be > 0")
+	}
+	a.workers = v
+	return nil
+}
+
+func (a *asyncConfig) SetQueueSize(v int) error {
+	if v < 0 {
+		return errors.New("invalid queue size: must be >= 0")
+	}
+	a.queueSize = v
+	return nil
+}
+
+func (a *asyncConfig) SetPlugin(pluginID string, workers int, queueSize int) error {
+	if workers <= 0 {
+		return errors.New("invalid workers: must be > 0")
+	}
+	if queueSize < 0 {
+		return errors.New("invalid queue size: must be >= 0")
+	}
+	a.plugins[pluginID] = asyncPoolConfig{workers: workers, queueSize: queueSize}
+	return nil
+}
+
+func (a *asyncConfig) SetPolicy(v AsyncPolicy) error {
+	switch v {
+	case AsyncPolicyBlock, AsyncPolicyDrop, AsyncPolicyError:
+		a.policy = v
+		return nil
+	}
+	return errors.New("invalid async policy")
+}
+
+func (a *asyncConfig) SetContextCopiers(v ...ContextCopier) error {
+	a.copiers = append(a.copiers, v...)
+	return nil
+}
+
+func (a *asyncConfig) SetOnError(v func(c context.Context, pluginID string, err error)) error {
+	if v == nil {
+		return errors.New("invalid error callback: must not be nil")
+	}
+	a.onError = v
+	return nil
+}
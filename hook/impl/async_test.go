@@ -0,0 +1,138 @@
+package impl_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hypershadow-io/contract/eb"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/hook/impl"
+)
+
+type activePlugins struct{}
+
+func (activePlugins) IsSystem(string) bool                  { return false }
+func (activePlugins) IsActive(context.Context, string) bool { return true }
+
+func TestAsyncEvents_Dispatch(t *testing.T) {
+	events := impl.NewEvents[int](activePlugins{})
+	var (
+		sum     atomic.Int64
+		mu      sync.Mutex
+		handled []string
+	)
+	events.Registry("a").Add(nil, func(_ context.Context, kinds hook.Kinds, value int) error {
+		if !kinds.Has(hook.KindCreate) {
+			t.Errorf("kinds are not preserved: %v", kinds)
+		}
+		sum.Add(int64(value))
+		return nil
+	})
+	events.Registry("b").Add(nil, func(context.Context, hook.Kinds, int) error {
+		panic("boom")
+	})
+	dispatcher, err := impl.NewAsyncEvents(events, impl.WithAsyncOnError(
+		func(_ context.Context, pluginID string, err error) {
+			mu.Lock()
+			var bErr eb.Builder
+			if errors.As(err, &bErr) {
+				handled = append(handled, pluginID+": "+bErr.GetKey())
+			}
+			mu.Unlock()
+		},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = dispatcher.Run() }()
+
+	c, cancel := context.WithCancel(context.Background())
+	for i := 1; i <= 10; i++ {
+		if err = dispatcher.Dispatch(c, hook.NewKinds(hook.KindCreate), i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cancel()
+	dispatcher.Dispose()
+
+	if got := sum.Load(); got != 55 {
+		t.Errorf("sum = %d, want 55", got)
+	}
+	if len(handled) != 10 || handled[0] != "b: "+impl.KeyHookPanic {
+		t.Errorf("panics are not isolated: %v", handled)
+	}
+	if err = dispatcher.Dispatch(context.Background(), nil, 1); !errors.Is(err, impl.ErrAsyncClosed) {
+		t.Errorf("Dispatch() after Dispose error = %v, want %v", err, impl.ErrAsyncClosed)
+	}
+}
+
+func TestAsyncEvents_PolicyError(t *testing.T) {
+	events := impl.NewEvents[int](activePlugins{})
+	release := make(chan struct{})
+	events.Registry("slow").Add(nil, func(context.Context, hook.Kinds, int) error {
+		<-release
+		return nil
+	})
+	dispatcher, err := impl.NewAsyncEvents(events,
+		impl.WithAsyncPlugin("slow", 1, 1),
+		impl.WithAsyncPolicy(impl.AsyncPolicyError),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dispatcher.Dispose()
+	defer close(release)
+
+	var full bool
+	for i := 0; i < 3 && !full; i++ {
+		full = errors.Is(dispatcher.Dispatch(context.Background(), nil, i), impl.ErrAsyncQueueFull)
+	}
+	if !full {
+		t.Errorf("Dispatch() did not report %v", impl.ErrAsyncQueueFull)
+	}
+}
+
+func TestAsyncEvents_DisposeBlocked(t *testing.T) {
+	events := impl.NewEvents[int](activePlugins{})
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	events.Registry("slow").Add(nil, func(context.Context, hook.Kinds, int) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+	dispatcher, err := impl.NewAsyncEvents(events, impl.WithAsyncPlugin("slow", 1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 2 {
+		if err = dispatcher.Dispatch(context.Background(), nil, i); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			<-started
+		}
+	}
+	blocked := make(chan error)
+	go func() { blocked <- dispatcher.Dispatch(context.Background(), nil, 2) }()
+	disposed := make(chan struct{})
+	go func() {
+		dispatcher.Dispose()
+		close(disposed)
+	}()
+
+	select {
+	case err = <-blocked:
+		if !errors.Is(err, impl.ErrAsyncClosed) {
+			t.Errorf("blocked Dispatch() error = %v, want %v", err, impl.ErrAsyncClosed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Dispose did not release the blocked Dispatch")
+	}
+	close(release)
+	<-disposed
+}
@@ -0,0 +1,274 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"time"
+
+	"github.com/hypershadow-io/contract/eb"
+	ebimpl "github.com/hypershadow-io/contract/eb/impl"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/meta"
+)
+
+// ProtectMutators wraps the mutator collection so that every handler returned by Find
+// recovers from panics and is bounded by a context deadline.
+// A panicked or timed out handler returns the unmodified value together with an eb.Builder error
+// tagged with the plugin ID; a handler that returned keeps its result, even alongside an error (e.g. hook.Halt).
+func ProtectMutators[T any](mutators Mutators[T], opts ...ProtectOption) (Mutators[T], error) {
+	cfg, err := makeProtectConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &protected[hook.MutatorFunc[T], T]{
+		Collection: mutators,
+		wrap: func(pluginID string, handler hook.MutatorFunc[T]) hook.MutatorFunc[T] {
+			return func(c context.Context, kinds hook.Kinds, value T) (T, error) {
+				var (
+					result   T
+					returned bool
+				)
+				completed, err := cfg.call(c, pluginID, func(c context.Context) error {
+					patch, err := handler(c, kinds, value)
+					result, returned = patch, true
+					return err
+				})
+				// the result of a handler still running after its deadline must not be read
+				if !completed || !returned {
+					return value, err
+				}
+				return result, err
+			}
+		},
+	}, nil
+}
+
+// ProtectEvents wraps the event collection so that every handler returned by Find
+// recovers from panics and is bounded by a context deadline.
+func ProtectEvents[T any](events Events[T], opts ...ProtectOption) (Events[T], error) {
+	cfg, err := makeProtectConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &protected[hook.EventFunc[T], T]{
+		Collection: events,
+		wrap: func(pluginID string, handler hook.EventFunc[T]) hook.EventFunc[T] {
+			return func(c context.Context, kinds hook.Kinds, value T) error {
+				_, err := cfg.call(c, pluginID, func(c context.Context) error { return handler(c, kinds, value) })
+				return err
+			}
+		},
+	}, nil
+}
+
+// WithProtectTimeout sets the default deadline for a single handler call.
+// Zero disables the deadline.
+func WithProtectTimeout(v time.Duration) ProtectOption {
+	return func(opt protectOption) error { return opt.SetTimeout(v) }
+}
+
+// WithProtectPluginTimeout overrides the handler deadline for the given plugin.
+func WithProtectPluginTimeout(pluginID string, v time.Duration) ProtectOption {
+	return func(opt protectOption) error { return opt.SetPluginTimeout(pluginID, v) }
+}
+
+// WithProtectOnFault registers a callback invoked every time a handler panics or exceeds its deadline.
+// Useful for recording misbehaving plugins (metrics, alerts, automatic deactivation).
+func WithProtectOnFault(v func(c context.Context, pluginID string, err eb.Builder)) ProtectOption {
+	return func(opt protectOption) error { return opt.SetOnFault(v) }
+}
+
+// Error keys of the errors produced by the protective wrapper.
+const (
+	KeyHookPanic   = "hook.panic"
+	KeyHookTimeout = "hook.timeout"
+)
+
+// MetaPluginID is the meta key holding the ID of the plugin whose handler failed.
+const MetaPluginID = "pluginId"
+
+type (
+	// ErrHookPanic is the base error of a handler that panicked.
+	ErrHookPanic struct{}
+
+	// ErrHookTimeout is the base error of a handler that did not finish before its deadline.
+	ErrHookTimeout struct{}
+
+	// ProtectOption represents a functional option used to configure the protective wrapper.
+	ProtectOption func(protectOption) error
+
+	// protectOption defines methods for configuring the protective wrapper.
+	protectOption interface {
+		// SetTimeout sets the default handler deadline.
+		SetTimeout(time.Duration) error
+
+		// SetPluginTimeout overrides the handler deadline for a specific plugin.
+		SetPluginTimeout(pluginID string, v time.Duration) error
+
+		// SetOnFault sets the callback for panicked or timed out handlers.
+		SetOnFault(func(c context.Context, pluginID string, err eb.Builder)) error
+	}
+
+	// protectConfig is the internal implementation of protectOption.
+	protectConfig struct {
+		timeout  time.Duration
+		timeouts map[string]time.Duration // per-plugin overrides
+		onFault  func(c context.Context, pluginID string, err eb.Builder)
+	}
+
+	// protected is a collection whose handlers are wrapped on retrieval.
+	protected[H any, V any] struct {
+		Collection[H, V]
+		wrap func(pluginID string, handler H) H
+	}
+)
+
+func (ErrHookPanic) Error() string   { return "hook panic" }
+func (ErrHookTimeout) Error() string { return "hook timeout" }
+
+func (a *protected[H, V]) Find(c context.Context, kinds hook.Kinds, value V) iter.Seq[H] {
+	return func(yield func(H) bool) {
+		for pluginID, handler := range a.Collection.FindWithPluginID(c, kinds, value) {
+			if !yield(a.wrap(pluginID, handler)) {
+				return
+			}
+		}
+	}
+}
+
+func (a *protected[H, V]) FindWithPluginID(c context.Context, kinds hook.Kinds, value V) iter.Seq2[string, H] {
+	return func(yield func(string, H) bool) {
+		for pluginID, handler := range a.Collection.FindWithPluginID(c, kinds, value) {
+			if !yield(pluginID, a.wrap(pluginID, handler)) {
+				return
+			}
+		}
+	}
+}
+
+// errPluginDeadline is the cause of handler contexts cancelled by the plugin deadline.
+var errPluginDeadline = errors.New("hook: plugin deadline exceeded")
+
+func init() {
+	// recovered panics are attributed to the panicking handler
+	ebimpl.AddStackHelpers(
+		"github.com/hypershadow-io/contract/hook/impl.newPanicError",
+		"github.com/hypershadow-io/contract/hook/impl.callRecover",
+	)
+}
+
+// makeProtectConfig applies the options on top of the defaults.
+func makeProtectConfig(opts []ProtectOption) (*protectConfig, error) {
+	cfg := &protectConfig{
+		timeouts: make(map[string]time.Duration),
+		onFault:  func(context.Context, string, eb.Builder) {},
+	}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// call runs the handler with panic recovery and the plugin deadline.
+// If the deadline is exceeded, the result of the still running handler is discarded and completed_ is false.
+func (a *protectConfig) call(
+	c context.Context,
+	pluginID string,
+	handler func(c context.Context) error,
+) (completed_ bool, err_ error) {
+	timeout, ok := a.timeouts[pluginID]
+	if !ok {
+		timeout = a.timeout
+	}
+	if timeout <= 0 {
+		return true, a.report(c, pluginID, callRecover(c, pluginID, handler))
+	}
+	cTimeout, cancel := context.WithTimeoutCause(c, timeout, errPluginDeadline)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- callRecover(cTimeout, pluginID, handler) }()
+	select {
+	case err := <-done:
+		return true, a.report(c, pluginID, err)
+	case <-cTimeout.Done():
+		if context.Cause(cTimeout) != errPluginDeadline {
+			// the caller gave up (e.g. the client disconnected); the plugin is not at fault
+			return false, context.Cause(c)
+		}
+		return false, a.report(c, pluginID, newTimeoutError(pluginID, timeout))
+	}
+}
+
+// report notifies the fault callback if the error was produced by the wrapper itself.
+func (a *protectConfig) report(c context.Context, pluginID string, err error) error {
+	switch bErr := err.(type) {
+	case ebimpl.Builder[ErrHookPanic]:
+		a.onFault(c, pluginID, bErr)
+	case ebimpl.Builder[ErrHookTimeout]:
+		a.onFault(c, pluginID, bErr)
+	}
+	return err
+}
+
+func (a *protectConfig) SetTimeout(v time.Duration) error {
+	if v < 0 {
+		return errors.New("invalid timeout: must be >= 0")
+	}
+	a.timeout = v
+	return nil
+}
+
+func (a *protectConfig) SetPluginTimeout(pluginID string, v time.Duration) error {
+	if v < 0 {
+		return errors.New("invalid timeout: must be >= 0")
+	}
+	a.timeouts[pluginID] = v
+	return nil
+}
+
+func (a *protectConfig) SetOnFault(v func(c context.Context, pluginID string, err eb.Builder)) error {
+	if v == nil {
+		return errors.New("invalid fault callback: must not be nil")
+	}
+	a.onFault = v
+	return nil
+}
+
+// callRecover invokes the handler, converting a panic into an eb.Builder error.
+func callRecover(c context.Context, pluginID string, handler func(c context.Context) error) (err_ error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err_ = newPanicError(pluginID, v)
+		}
+	}()
+	return handler(c)
+}
+
+// newPanicError builds the error returned for a recovered handler panic.
+func newPanicError(pluginID string, value any) eb.Builder {
+	result := ebimpl.Make[ErrHookPanic]().
+		SetKey(KeyHookPanic).
+		SetCode(500).
+		SetMessagef("internal error").
+		SetMeta(meta.Make(1).Set(MetaPluginID, pluginID))
+	switch v := value.(type) {
+	case error:
+		return result.SetLogMessagef("plugin " + pluginID + " hook panic: " + v.Error()).AddWrap(v)
+	case string:
+		return result.SetLogMessagef("plugin " + pluginID + " hook panic: " + v)
+	}
+	return result.SetLogMessagef("plugin " + pluginID + " hook panic")
+}
+
+// newTimeoutError builds the error returned for a handler that exceeded its deadline.
+func newTimeoutError(pluginID string, timeout time.Duration) eb.Builder {
+	return ebimpl.Make[ErrHookTimeout]().
+		SetKey(KeyHookTimeout).
+		SetCode(500).
+		SetMessagef("internal error").
+		SetLogMessagef("plugin " + pluginID + " hook exceeded deadline of " + timeout.String()).
+		SetMeta(meta.Make(1).Set(MetaPluginID, pluginID))
+}
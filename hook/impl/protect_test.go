@@ -0,0 +1,77 @@
+package impl_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hypershadow-io/contract/eb"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/hook/impl"
+)
+
+func TestProtectMutators(t *testing.T) {
+	mutators := impl.NewMutators[int](activePlugins{})
+	mutators.Registry("halt").Add(nil, func(_ context.Context, _ hook.Kinds, value int) (int, error) {
+		return value + 1, hook.Halt()
+	})
+	mutators.Registry("panic").Add(nil, func(context.Context, hook.Kinds, int) (int, error) {
+		panic("boom")
+	})
+	mutators.Registry("slow").Add(nil, func(c context.Context, _ hook.Kinds, value int) (int, error) {
+		<-c.Done()
+		return value + 100, nil
+	})
+	protected, err := impl.ProtectMutators(mutators, impl.WithProtectTimeout(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for pluginID, handler := range protected.FindWithPluginID(context.Background(), nil, 1) {
+		value, err := handler(context.Background(), nil, 1)
+		switch pluginID {
+		case "halt":
+			if value != 2 || !errors.Is(err, hook.Halt()) {
+				t.Errorf("halt: got (%d, %v), want the handler result with the signal", value, err)
+			}
+		case "panic", "slow":
+			if value != 1 || err == nil {
+				t.Errorf("%s: got (%d, %v), want the input value with an error", pluginID, value, err)
+			}
+		}
+	}
+}
+
+func TestProtectMutators_Cancelled(t *testing.T) {
+	mutators := impl.NewMutators[int](activePlugins{})
+	started := make(chan struct{})
+	mutators.Registry("slow").Add(nil, func(c context.Context, _ hook.Kinds, value int) (int, error) {
+		close(started)
+		<-c.Done()
+		return value, nil
+	})
+	var faults int
+	protected, err := impl.ProtectMutators(mutators,
+		impl.WithProtectTimeout(time.Minute),
+		impl.WithProtectOnFault(func(context.Context, string, eb.Builder) { faults++ }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, cancel := context.WithCancelCause(context.Background())
+	errGone := errors.New("client disconnected")
+	go func() {
+		<-started
+		cancel(errGone)
+	}()
+	for _, handler := range protected.FindWithPluginID(c, nil, 1) {
+		if _, err = handler(c, nil, 1); !errors.Is(err, errGone) {
+			t.Errorf("error = %v, want the cause of the caller context", err)
+		}
+	}
+	if faults != 0 {
+		t.Errorf("faults = %d, want none for a cancelled caller", faults)
+	}
+}
@@ -0,0 +1,115 @@
+package impl
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/hook/trace"
+)
+
+// sqlQuery is implemented by query builders whose SQL is captured around SQL mutators.
+type sqlQuery interface {
+	ToSql() (sql_ string, args_ []any, err_ error)
+}
+
+// traceHandler records the filter decision of the entry and, for matched entries,
+// wraps the handler so that its duration, error, and SQL snapshots are recorded after execution.
+// A handler abandoned when its context is done (e.g. the deadline of ProtectMutators) is recorded
+// with the cause at that moment, and its late result is ignored.
+func traceHandler[H any, V any](
+	collector *trace.Collector,
+	e entry[H, V],
+	kinds hook.Kinds,
+	matched bool,
+) H {
+	index := collector.Add(trace.Record{
+		Hook:     reflect.TypeFor[V]().String(),
+		PluginID: e.pluginID,
+		Kinds:    kinds.String(),
+		Matched:  matched,
+	})
+	if !matched {
+		return e.handler
+	}
+	switch handler := any(e.handler).(type) {
+	case hook.MutatorFunc[V]:
+		var wrapped hook.MutatorFunc[V] = func(c context.Context, kinds hook.Kinds, value V) (V, error) {
+			before := traceSQL(value)
+			start := time.Now()
+			stop := traceAbandon(c, collector, index, start, before)
+			patch, err := handler(c, kinds, value)
+			duration := time.Since(start)
+			if !stop() {
+				return patch, err
+			}
+			collector.Update(index, func(record *trace.Record) {
+				record.Executed = true
+				record.Duration = duration
+				record.SQLBefore = before
+				if err != nil {
+					record.Error = err.Error()
+				} else {
+					record.SQLAfter = traceSQL(patch)
+				}
+			})
+			return patch, err
+		}
+		return any(wrapped).(H)
+	case hook.EventFunc[V]:
+		var wrapped hook.EventFunc[V] = func(c context.Context, kinds hook.Kinds, value V) error {
+			start := time.Now()
+			stop := traceAbandon(c, collector, index, start, nil)
+			err := handler(c, kinds, value)
+			duration := time.Since(start)
+			if !stop() {
+				return err
+			}
+			collector.Update(index, func(record *trace.Record) {
+				record.Executed = true
+				record.Duration = duration
+				if err != nil {
+					record.Error = err.Error()
+				}
+			})
+			return err
+		}
+		return any(wrapped).(H)
+	}
+	return e.handler
+}
+
+// traceAbandon records the cause of the context once it is done while the handler is running.
+// The returned function stops the watch and reports false if the cause has already been recorded.
+func traceAbandon(
+	c context.Context,
+	collector *trace.Collector,
+	index int,
+	start time.Time,
+	before *trace.SQL,
+) func() bool {
+	return context.AfterFunc(c, func() {
+		duration := time.Since(start)
+		collector.Update(index, func(record *trace.Record) {
+			record.Executed = true
+			record.Duration = duration
+			record.SQLBefore = before
+			record.Error = context.Cause(c).Error()
+		})
+	})
+}
+
+// traceSQL renders the value if it is an SQL query builder.
+func traceSQL(value any) *trace.SQL {
+	query, ok := value.(sqlQuery)
+	if !ok {
+		return nil
+	}
+	sql, args, err := query.ToSql()
+	result := &trace.SQL{Query: sql, Args: args}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
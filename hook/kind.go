@@ -122,3 +122,20 @@ func (a Kinds) String() string {
 	}
 	return ""
 }
+
+// Set converts the kinds into a KindSet.
+// Returns ErrUnknownKind if any of the kinds is not registered (see RegisterKind).
+func (a Kinds) Set() (KindSet, error) {
+	var result KindSet
+	for item, ok := range a {
+		if !ok {
+			continue
+		}
+		i, found := item.lookup()
+		if !found {
+			return KindSet{}, ErrUnknownKind{Kind: item}
+		}
+		result = result.set(i)
+	}
+	return result, nil
+}
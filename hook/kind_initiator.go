@@ -1,12 +1,20 @@
 package hook
 
-import "sync"
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
 
 // AddInitiator registers a new Kind as an initiator kind.
 // Initiator kinds are used to identify the source or trigger type of an operation (e.g. UI, System).
+// The kind is registered in the registry of known kinds as well (see RegisterKind).
 func AddInitiator(kind Kind) {
 	locker.Lock()
 	initiatorKinds = append(initiatorKinds, kind)
+	set := initiatorSet.Load().set(RegisterKind(kind).index())
+	initiatorSet.Store(&set)
 	locker.Unlock()
 }
 
@@ -14,9 +22,15 @@ var (
 	// initiatorKinds holds the list of known initiator kinds (e.g., UI, System, Silent).
 	// These are used to identify the origin of a request or action.
 	initiatorKinds = []Kind{KindUI, KindSystem, KindSilent}
+	initiatorSet   atomic.Pointer[KindSet] // initiatorKinds as a set, read without the lock
 	locker         sync.RWMutex
 )
 
+func init() {
+	set := MustKindSet(initiatorKinds...)
+	initiatorSet.Store(&set)
+}
+
 // PickInitiatorKinds returns a new Kinds set containing only the kinds
 // from the current set that match known initiator kinds (e.g., UI, System, Silent).
 func (a Kinds) PickInitiatorKinds() Kinds {
@@ -25,3 +39,27 @@ func (a Kinds) PickInitiatorKinds() Kinds {
 	locker.RUnlock()
 	return result
 }
+
+// PickInitiatorKinds returns a new set containing only the initiator kinds of the current set.
+func (a KindSet) PickInitiatorKinds() KindSet {
+	return a.intersect(*initiatorSet.Load())
+}
+
+// MatchInitiatorKinds returns a filter that matches if at least one of the specified kinds
+// is present in the kinds as an initiator kind, like kinds.PickInitiatorKinds().HasAny(list...) without allocations.
+func MatchInitiatorKinds[T any](list ...Kind) Filter[T] {
+	mask, masked := maskOf(list)
+	return func(c context.Context, kinds Kinds, _ T) bool {
+		if set, ok := matchedSet(c, kinds, masked); ok {
+			return set.Intersects(mask.intersect(*initiatorSet.Load()))
+		}
+		locker.RLock()
+		defer locker.RUnlock()
+		for _, item := range list {
+			if kinds[item] && slices.Contains(initiatorKinds, item) {
+				return true
+			}
+		}
+		return false
+	}
+}
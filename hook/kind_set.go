@@ -0,0 +1,362 @@
+package hook
+
+import (
+	"encoding/json"
+	"iter"
+	"math/bits"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// NewKindSet creates a KindSet from a list of registered kinds.
+// Returns ErrUnknownKind if any of the kinds is not registered (see RegisterKind).
+func NewKindSet(list ...Kind) (KindSet, error) {
+	return KindSet{}.With(list...)
+}
+
+// MustKindSet is like NewKindSet but panics if any of the kinds is not registered.
+// Intended for package-level variables built from registered kinds.
+func MustKindSet(list ...Kind) KindSet {
+	result, err := NewKindSet(list...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// RegisterKind interns the kind in the registry of known kinds and returns it,
+// so that it can be stored in a KindSet. The base kinds are registered by default.
+// Registering kinds up front (e.g. in package variables) assigns them stable low bit indices;
+// the first 64 registered kinds are stored without allocations.
+func RegisterKind(kind Kind) Kind {
+	if _, ok := kind.lookup(); ok {
+		return kind
+	}
+	registry.locker.Lock()
+	defer registry.locker.Unlock()
+	if _, ok := kind.lookup(); ok {
+		return kind
+	}
+	var names []Kind
+	if current := registry.names.Load(); current != nil {
+		names = *current
+	}
+	// readers hold shorter slice headers, so appending in place does not affect them
+	names = append(names, kind)
+	registry.names.Store(&names)
+	registry.index.Store(kind, len(names)-1)
+	return kind
+}
+
+type (
+	// KindSet is an immutable set of registered Kind values stored as bits indexed by the registry of known kinds.
+	// It is an allocation-free alternative to Kinds for hot paths; the zero value is an empty set.
+	// Convert between the representations using Kinds.Set and KindSet.Kinds.
+	KindSet struct {
+		bits uint64   // kinds with indices below 64
+		rest []uint64 // kinds with indices from 64, allocated only if used
+	}
+
+	// ErrUnknownKind is returned when a KindSet is built from a kind missing in the registry.
+	ErrUnknownKind struct {
+		Kind Kind
+	}
+)
+
+// registry holds known kinds; lookups are lock-free, registration is serialized.
+var registry struct {
+	locker sync.Mutex
+	index  sync.Map               // bit index by kind
+	names  atomic.Pointer[[]Kind] // kinds by bit index
+}
+
+// baseKinds are the base kinds, registered during package variable initialization,
+// so that they are available to init functions.
+var baseKinds = registerKinds(
+	KindBefore, KindAfter,
+	KindFind, KindCreate, KindUpdate, KindDelete,
+	KindLock, KindOne, KindMany, KindByID,
+	KindUI, KindSystem, KindSilent,
+)
+
+func (a ErrUnknownKind) Error() string { return "hook: unknown kind: " + string(a.Kind) }
+
+// With returns a new set containing the current kinds plus the provided ones.
+// Returns ErrUnknownKind if any of the kinds is not registered.
+func (a KindSet) With(list ...Kind) (KindSet, error) {
+	result := a
+	for _, item := range list {
+		i, ok := item.lookup()
+		if !ok {
+			return a, ErrUnknownKind{Kind: item}
+		}
+		result = result.set(i)
+	}
+	return result, nil
+}
+
+// Union returns a new set containing the kinds of both sets.
+func (a KindSet) Union(other KindSet) KindSet {
+	result := KindSet{bits: a.bits | other.bits}
+	if n := max(len(a.rest), len(other.rest)); n > 0 {
+		result.rest = make([]uint64, n)
+		for i := range n {
+			result.rest[i] = word(a.rest, i) | word(other.rest, i)
+		}
+	}
+	return result
+}
+
+// Pick returns a new set containing only the kinds from the input list that are present in the original set.
+func (a KindSet) Pick(list ...Kind) KindSet {
+	var result KindSet
+	for _, item := range list {
+		if i, ok := item.lookup(); ok && a.has(i) {
+			result = result.set(i)
+		}
+	}
+	return result
+}
+
+// Has checks whether the given kind is present in the set.
+func (a KindSet) Has(item Kind) bool {
+	i, ok := item.lookup()
+	return ok && a.has(i)
+}
+
+// Not returns true if the given kind is not present in the set.
+func (a KindSet) Not(item Kind) bool {
+	return !a.Has(item)
+}
+
+// HasAll checks if all provided kinds are present in the set.
+func (a KindSet) HasAll(list ...Kind) bool {
+	for _, item := range list {
+		if !a.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny checks if at least one of the provided kinds is present in the set.
+func (a KindSet) HasAny(list ...Kind) bool {
+	for _, item := range list {
+		if a.Has(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasOnly checks if the set contains only the specified kinds (no more, no less).
+func (a KindSet) HasOnly(list ...Kind) bool {
+	var other KindSet
+	for _, item := range list {
+		i, ok := item.lookup()
+		if !ok || !a.has(i) {
+			return false
+		}
+		other = other.set(i)
+	}
+	return a.Equal(other)
+}
+
+// Contains checks if all kinds of the other set are present in the set.
+// Prefer it over HasAll on hot paths with a set prepared in advance.
+func (a KindSet) Contains(other KindSet) bool {
+	if a.bits&other.bits != other.bits {
+		return false
+	}
+	for i, w := range other.rest {
+		if word(a.rest, i)&w != w {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersects checks if at least one kind of the other set is present in the set.
+// Prefer it over HasAny on hot paths with a set prepared in advance.
+func (a KindSet) Intersects(other KindSet) bool {
+	if a.bits&other.bits != 0 {
+		return true
+	}
+	for i, w := range other.rest {
+		if word(a.rest, i)&w != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal reports whether both sets contain the same kinds.
+func (a KindSet) Equal(other KindSet) bool {
+	if a.bits != other.bits {
+		return false
+	}
+	for i := range max(len(a.rest), len(other.rest)) {
+		if word(a.rest, i) != word(other.rest, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of kinds in the set.
+func (a KindSet) Len() int {
+	result := bits.OnesCount64(a.bits)
+	for _, w := range a.rest {
+		result += bits.OnesCount64(w)
+	}
+	return result
+}
+
+// All returns a sequence of kinds in the set, in registration order.
+func (a KindSet) All() iter.Seq[Kind] {
+	return func(yield func(Kind) bool) {
+		names := loadNames()
+		for offset, w := range a.words() {
+			for w != 0 {
+				i := bits.TrailingZeros64(w)
+				if !yield(names[offset+i]) {
+					return
+				}
+				w &^= 1 << i
+			}
+		}
+	}
+}
+
+// Kinds converts the set into Kinds.
+func (a KindSet) Kinds() Kinds {
+	result := make(Kinds, a.Len())
+	for item := range a.All() {
+		result[item] = true
+	}
+	return result
+}
+
+// String returns a comma-separated list of kind names in the set.
+func (a KindSet) String() string {
+	var builder strings.Builder
+	for item := range a.All() {
+		if builder.Len() > 0 {
+			builder.WriteRune(',')
+		}
+		builder.WriteString(item.String())
+	}
+	return builder.String()
+}
+
+// MarshalJSON encodes the set as an object of kind names, like Kinds.
+func (a KindSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Kinds())
+}
+
+// UnmarshalJSON decodes the set from an object of kind names.
+// Returns ErrUnknownKind if any of the kinds is not registered.
+func (a *KindSet) UnmarshalJSON(data []byte) error {
+	var list Kinds
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	result, err := list.Set()
+	if err != nil {
+		return err
+	}
+	*a = result
+	return nil
+}
+
+// has checks whether the bit with the given index is set.
+func (a KindSet) has(i int) bool {
+	if i < 64 {
+		return a.bits&(1<<i) != 0
+	}
+	return word(a.rest, i/64-1)&(1<<(i%64)) != 0
+}
+
+// set returns a copy of the set with the bit of the given index set.
+func (a KindSet) set(i int) KindSet {
+	if i < 64 {
+		a.bits |= 1 << i
+		return a
+	}
+	if a.has(i) {
+		return a
+	}
+	n := i/64 - 1
+	rest := make([]uint64, max(len(a.rest), n+1))
+	copy(rest, a.rest)
+	rest[n] |= 1 << (i % 64)
+	a.rest = rest
+	return a
+}
+
+// words returns the sequence of bit words with their index offsets.
+func (a KindSet) words() iter.Seq2[int, uint64] {
+	return func(yield func(int, uint64) bool) {
+		if !yield(0, a.bits) {
+			return
+		}
+		for n, w := range a.rest {
+			if !yield((n+1)*64, w) {
+				return
+			}
+		}
+	}
+}
+
+// intersect returns the kinds present in both sets.
+func (a KindSet) intersect(other KindSet) KindSet {
+	result := KindSet{bits: a.bits & other.bits}
+	if n := min(len(a.rest), len(other.rest)); n > 0 {
+		result.rest = make([]uint64, n)
+		for i := range n {
+			result.rest[i] = a.rest[i] & other.rest[i]
+		}
+	}
+	return result
+}
+
+// lookup returns the bit index of the kind if it is registered.
+func (a Kind) lookup() (int, bool) {
+	i, ok := registry.index.Load(a)
+	if !ok {
+		return 0, false
+	}
+	return i.(int), true
+}
+
+// registerKinds registers the kinds and returns them as a set.
+func registerKinds(list ...Kind) KindSet {
+	var result KindSet
+	for _, kind := range list {
+		result = result.set(RegisterKind(kind).index())
+	}
+	return result
+}
+
+// loadNames returns the registered kinds by bit index.
+func loadNames() []Kind {
+	if result := registry.names.Load(); result != nil {
+		return *result
+	}
+	return nil
+}
+
+// word returns the n-th word of the list, or zero if out of range.
+func word(list []uint64, n int) uint64 {
+	if n < len(list) {
+		return list[n]
+	}
+	return 0
+}
+
+// index returns the bit index of a registered kind.
+func (a Kind) index() int {
+	i, _ := a.lookup()
+	return i
+}
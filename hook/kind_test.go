@@ -0,0 +1,197 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// Sinks keeping benchmark results alive.
+var (
+	sinkKindSet KindSet
+	sinkKinds   Kinds
+	sinkBool    bool
+)
+
+func TestKindSet(t *testing.T) {
+	extra := make([]Kind, 0, 80)
+	for i := range 80 {
+		extra = append(extra, RegisterKind(Kind("TestKind"+strconv.Itoa(i))))
+	}
+	base := MustKindSet(KindCreate, KindUI)
+	kinds, err := base.With(extra[70], KindBefore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !kinds.HasAll(KindCreate, KindUI, KindBefore, extra[70]) || kinds.Has(extra[71]) || kinds.Has("Unknown") {
+		t.Fatalf("unexpected membership: %s", kinds)
+	}
+	if base.Has(extra[70]) || base.Len() != 2 {
+		t.Fatalf("With mutated the original set: %s", base)
+	}
+	if !kinds.HasOnly(KindBefore, KindCreate, KindUI, extra[70]) || kinds.HasOnly(KindCreate, KindUI) {
+		t.Fatalf("unexpected HasOnly result: %s", kinds)
+	}
+	if got := kinds.PickInitiatorKinds(); !got.Equal(MustKindSet(KindUI)) {
+		t.Fatalf("unexpected initiator kinds: %s", got)
+	}
+	data, err := kinds.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded KindSet
+	if err = decoded.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(kinds) {
+		t.Fatalf("round trip mismatch: %s != %s", decoded, kinds)
+	}
+	if converted, err := kinds.Kinds().Set(); err != nil || !converted.Equal(kinds) {
+		t.Fatalf("conversion mismatch: %s != %s (%v)", converted, kinds, err)
+	}
+}
+
+func TestKindSet_Unknown(t *testing.T) {
+	var target ErrUnknownKind
+	if _, err := NewKindSet(KindCreate, "NotRegistered"); !errors.As(err, &target) || target.Kind != "NotRegistered" {
+		t.Errorf("NewKindSet() error = %v, want %T", err, target)
+	}
+	if _, err := MustKindSet(KindCreate).With("NotRegistered"); !errors.As(err, &target) {
+		t.Errorf("With() error = %v, want %T", err, target)
+	}
+	var decoded KindSet
+	if err := decoded.UnmarshalJSON([]byte(`{"NotRegistered":true}`)); !errors.As(err, &target) {
+		t.Errorf("UnmarshalJSON() error = %v, want %T", err, target)
+	}
+	if _, ok := Kind("NotRegistered").lookup(); ok {
+		t.Error("unknown kind was registered")
+	}
+}
+
+func TestFilters_MatchedKinds(t *testing.T) {
+	filters := map[string]Filter[int]{
+		"kind":          MatchKind[int](KindCreate),
+		"exclude":       ExcludeKind[int](KindSystem),
+		"all":           MatchAllKinds[int](KindBefore, KindCreate),
+		"any":           MatchAnyKinds[int](KindUpdate, KindDelete),
+		"only":          MatchOnlyKinds[int](KindBefore, KindCreate, KindUI),
+		"initiator":     MatchInitiatorKinds[int](KindUI, KindCreate),
+		"unknown":       MatchAnyKinds[int]("NotRegistered", KindDelete),
+		"unknown only":  MatchOnlyKinds[int]("NotRegistered"),
+		"unknown exact": MatchAllKinds[int]("NotRegistered", KindBefore),
+	}
+	list := []Kinds{
+		nil,
+		NewKinds(KindBefore, KindCreate, KindUI),
+		NewKinds(KindBefore, KindCreate, KindUI, "NotRegistered"),
+		NewKinds("NotRegistered"),
+		NewKinds(KindAfter, KindDelete, KindSystem),
+		{KindBefore: true, KindCreate: true, KindUI: true, KindSystem: false},
+	}
+	for _, kinds := range list {
+		c := WithMatchedKinds(context.Background(), kinds)
+		for name, filter := range filters {
+			if got, want := filter(c, kinds, 0), filter(context.Background(), kinds, 0); got != want {
+				t.Errorf("%s(%v) = %v with the matched kinds, want %v", name, kinds, got, want)
+			}
+		}
+	}
+	// the matched kinds of another set are ignored
+	c := WithMatchedKinds(context.Background(), NewKinds(KindCreate))
+	if MatchKind[int](KindCreate)(c, NewKinds(KindDelete), 0) {
+		t.Error("filter used the matched kinds of another set")
+	}
+}
+
+func BenchmarkKindsWith(b *testing.B) {
+	b.Run("set", func(b *testing.B) {
+		b.ReportAllocs()
+		kinds := MustKindSet(KindFind, KindMany)
+		for b.Loop() {
+			sinkKindSet, _ = kinds.With(KindBefore, KindUI)
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		kinds := NewKinds(KindFind, KindMany)
+		for b.Loop() {
+			sinkKinds = kinds.With(KindBefore, KindUI)
+		}
+	})
+}
+
+func BenchmarkKindsHasAll(b *testing.B) {
+	b.Run("set", func(b *testing.B) {
+		b.ReportAllocs()
+		kinds := MustKindSet(KindBefore, KindFind, KindMany, KindUI)
+		for b.Loop() {
+			sinkBool = kinds.HasAll(KindBefore, KindFind, KindMany)
+		}
+	})
+	b.Run("set contains", func(b *testing.B) {
+		b.ReportAllocs()
+		kinds := MustKindSet(KindBefore, KindFind, KindMany, KindUI)
+		mask := MustKindSet(KindBefore, KindFind, KindMany)
+		for b.Loop() {
+			sinkBool = kinds.Contains(mask)
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		kinds := NewKinds(KindBefore, KindFind, KindMany, KindUI)
+		for b.Loop() {
+			sinkBool = kinds.HasAll(KindBefore, KindFind, KindMany)
+		}
+	})
+}
+
+func BenchmarkKindsPickInitiatorKinds(b *testing.B) {
+	b.Run("set", func(b *testing.B) {
+		b.ReportAllocs()
+		kinds := MustKindSet(KindBefore, KindFind, KindMany, KindUI)
+		for b.Loop() {
+			sinkKindSet = kinds.PickInitiatorKinds()
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		kinds := NewKinds(KindBefore, KindFind, KindMany, KindUI)
+		for b.Loop() {
+			sinkKinds = kinds.PickInitiatorKinds()
+		}
+	})
+}
+
+func BenchmarkKindFilters(b *testing.B) {
+	filters := []Filter[int]{
+		MatchAllKinds[int](KindBefore, KindFind, KindMany),
+		MatchAnyKinds[int](KindCreate, KindUpdate, KindDelete),
+		MatchOnlyKinds[int](KindBefore, KindFind),
+		ExcludeKind[int](KindSilent),
+		MatchInitiatorKinds[int](KindUI),
+	}
+	kinds := NewKinds(KindBefore, KindFind, KindMany, KindUI)
+	b.Run("set", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			c := WithMatchedKinds(context.Background(), kinds)
+			for range 4 {
+				for _, filter := range filters {
+					sinkBool = filter(c, kinds, 0)
+				}
+			}
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		c := context.Background()
+		for b.Loop() {
+			for range 4 {
+				for _, filter := range filters {
+					sinkBool = filter(c, kinds, 0)
+				}
+			}
+		}
+	})
+}
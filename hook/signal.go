@@ -0,0 +1,112 @@
+package hook
+
+import (
+	"context"
+	"errors"
+)
+
+// Halt returns a control signal that stops the mutator chain.
+// The value returned together with the signal is final; remaining handlers are not called.
+func Halt() error { return Signal{action: ActionHalt} }
+
+// Skip returns a control signal that stops the mutator chain and vetoes the operation:
+// the caller must not perform it, and no error is reported.
+func Skip() error { return Signal{action: ActionSkip} }
+
+// Replace returns a control signal that stops the mutator chain and replaces the operation
+// with another one (e.g. a qb.UpdateQuery instead of a qb.DeleteQuery for soft deletion).
+func Replace(replacement any) error { return Signal{action: ActionReplace, replacement: replacement} }
+
+// AsSignal reports whether the error is (or wraps) a control signal and returns it.
+func AsSignal(err error) (Signal, bool) {
+	var result Signal
+	ok := errors.As(err, &result)
+	return result, ok
+}
+
+// Mutate sequentially applies all mutators provided for the value and interprets control signals.
+// On a regular error the chain stops and the error is returned along with the value produced by the failing handler.
+func Mutate[T any](
+	c context.Context,
+	kinds Kinds,
+	provider Provider[MutatorFunc[T], T],
+	value T,
+) (Result[T], error) {
+	for handler := range provider.Find(c, kinds, value) {
+		patch, err := handler(c, kinds, value)
+		if err != nil {
+			if signal, ok := AsSignal(err); ok {
+				return Result[T]{
+					Value:       patch,
+					Action:      signal.action,
+					Replacement: signal.replacement,
+				}, nil
+			}
+			return Result[T]{Value: patch}, err
+		}
+		value = patch
+	}
+	return Result[T]{Value: value}, nil
+}
+
+// Control actions a mutator can request via a Signal.
+const (
+	// ActionNone means all matched handlers were applied.
+	ActionNone Action = iota
+
+	// ActionHalt means the chain was stopped and the value is final.
+	ActionHalt
+
+	// ActionSkip means the operation must not be performed.
+	ActionSkip
+
+	// ActionReplace means the operation must be replaced with Result.Replacement.
+	ActionReplace
+)
+
+type (
+	// Signal is a control signal returned by a mutator in place of an error.
+	// It changes the flow of the mutator chain instead of failing it.
+	Signal struct {
+		action      Action
+		replacement any
+	}
+
+	// Action defines how the caller must proceed after running a mutator chain.
+	Action int
+
+	// Result describes the outcome of a mutator chain.
+	Result[T any] struct {
+		Value       T      // final value
+		Action      Action // action requested by the handler that stopped the chain
+		Replacement any    // replacement operation, set only for ActionReplace
+	}
+)
+
+func (a Signal) Error() string {
+	switch a.action {
+	case ActionHalt:
+		return "hook: halt"
+	case ActionSkip:
+		return "hook: skip"
+	case ActionReplace:
+		return "hook: replace"
+	}
+	return "hook: signal"
+}
+
+// Action returns the action requested by the signal.
+func (a Signal) Action() Action { return a.action }
+
+// Replacement returns the replacement operation of an ActionReplace signal.
+func (a Signal) Replacement() any { return a.replacement }
+
+// Signal returns the control signal equivalent to the result action,
+// or nil if the caller can proceed with the value as usual (ActionNone, ActionHalt).
+func (a Result[T]) Signal() error {
+	switch a.Action {
+	case ActionSkip, ActionReplace:
+		return Signal{action: a.Action, replacement: a.Replacement}
+	}
+	return nil
+}
@@ -0,0 +1,3 @@
+module github.com/hypershadow-io/contract/hook/trace
+
+go 1.24.0
@@ -0,0 +1,20 @@
+module github.com/hypershadow-io/contract/hook/trace/httptrace
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/eb v1.3.0
+	github.com/hypershadow-io/contract/eb/impl v1.1.0
+	github.com/hypershadow-io/contract/hook/trace v1.0.0
+	github.com/hypershadow-io/contract/httpserver v1.0.2
+	github.com/hypershadow-io/contract/id v1.0.0
+)
+
+require (
+	github.com/hypershadow-io/contract/codec v1.0.0 // indirect
+	github.com/hypershadow-io/contract/fielderror v1.1.0 // indirect
+	github.com/hypershadow-io/contract/fmt v1.0.0 // indirect
+	github.com/hypershadow-io/contract/json v1.1.0 // indirect
+	github.com/hypershadow-io/contract/meta v1.0.0 // indirect
+	github.com/hypershadow-io/contract/meta/slog v1.0.0 // indirect
+)
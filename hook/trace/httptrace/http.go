@@ -0,0 +1,163 @@
+package httptrace
+
+import (
+	"context"
+	"sync"
+
+	ebimpl "github.com/hypershadow-io/contract/eb/impl"
+	"github.com/hypershadow-io/contract/hook/trace"
+	"github.com/hypershadow-io/contract/httpserver"
+	"github.com/hypershadow-io/contract/id"
+)
+
+// Headers used to request tracing and to return the ID of the collected trace.
+const (
+	HeaderTrace   = "X-Hook-Trace"
+	HeaderTraceID = "X-Hook-Trace-Id"
+)
+
+// Error keys returned by the debug route handler.
+const (
+	KeyNotFound  = "hook.trace.not_found" // the requested trace does not exist (or was evicted)
+	KeyForbidden = "hook.trace.forbidden" // the request is not allowed to read traces
+)
+
+// NewStore creates an in-memory store that keeps the given number of the most recent traces.
+func NewStore(size int) *Store {
+	return &Store{
+		size:  max(size, 1),
+		items: make(map[string]*trace.Collector, size),
+	}
+}
+
+// Middleware enables hook tracing for requests carrying the HeaderTrace header and allowed by the authorizer
+// (see WithAuthorizer; without it, the header is ignored). Traces expose the SQL and the plugins
+// of the request, so the authorizer should admit only platform administrators or debug environments.
+// The collected trace is saved into the store, and its ID is returned in the HeaderTraceID response header.
+func Middleware(
+	client httpserver.Client,
+	idClient id.Client,
+	store *Store,
+	opts ...Option,
+) httpserver.Handler {
+	o := makeOptions(opts)
+	return func(c context.Context) error {
+		ctx := client.CtxFromContext(c)
+		if ctx.GetHeader(HeaderTrace) == "" || !o.authorize(c) {
+			return ctx.Next(c)
+		}
+		collector := trace.NewCollector()
+		traceID := idClient.NewIDString()
+		store.Add(traceID, collector)
+		ctx.SetHeader(HeaderTraceID, traceID)
+		return ctx.Next(trace.ToContext(c, collector))
+	}
+}
+
+// Handler returns a debug route handler that responds with the records of the trace
+// identified by the "id" query parameter, encoded as JSON.
+// Requests rejected by the authorizer (all requests without WithAuthorizer) get a forbidden error.
+func Handler(
+	client httpserver.Client,
+	store *Store,
+	opts ...Option,
+) httpserver.Handler {
+	o := makeOptions(opts)
+	return httpserver.HandleQuery(client, func(c context.Context, in handlerIn) ([]trace.Record, error) {
+		if !o.authorize(c) {
+			return nil, ebimpl.Make[ErrForbidden]().
+				SetKey(KeyForbidden).
+				SetCode(httpserver.StatusForbidden).
+				SetMessagef("forbidden")
+		}
+		collector, ok := store.Get(in.ID)
+		if !ok {
+			return nil, ebimpl.Make[ErrNotFound]().
+				SetKey(KeyNotFound).
+				SetCode(httpserver.StatusNotFound).
+				SetMessagef("trace not found")
+		}
+		return collector.Records(), nil
+	})
+}
+
+// WithAuthorizer sets the function deciding whether the request may be traced and may read traces.
+func WithAuthorizer(v func(c context.Context) bool) Option {
+	return func(o option) { o.SetAuthorizer(v) }
+}
+
+type (
+	// Store keeps a bounded number of recent traces by ID.
+	// The oldest trace is evicted when the limit is reached.
+	Store struct {
+		locker sync.RWMutex
+		size   int
+		order  []string                    // trace IDs in insertion order
+		items  map[string]*trace.Collector // traces by ID
+	}
+
+	// Option defines a functional option for the tracing middleware and the debug route handler.
+	Option func(option)
+
+	// option is an internal interface used to apply configuration.
+	option interface {
+		// SetAuthorizer sets the function deciding whether the request may use tracing.
+		SetAuthorizer(func(c context.Context) bool)
+	}
+
+	// options holds settings of the tracing middleware and the debug route handler.
+	options struct {
+		authorize func(c context.Context) bool
+	}
+
+	// ErrNotFound is the base error of a missing trace.
+	ErrNotFound struct{}
+
+	// ErrForbidden is the base error of a request not allowed to read traces.
+	ErrForbidden struct{}
+
+	// handlerIn is the input of the debug route handler.
+	handlerIn struct {
+		ID string `query:"id"`
+	}
+)
+
+func (ErrNotFound) Error() string  { return "trace not found" }
+func (ErrForbidden) Error() string { return "trace forbidden" }
+
+// Add saves the collector under the given trace ID, evicting the oldest trace if needed.
+func (a *Store) Add(traceID string, collector *trace.Collector) {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	if _, ok := a.items[traceID]; !ok {
+		if len(a.order) >= a.size {
+			delete(a.items, a.order[0])
+			a.order = a.order[1:]
+		}
+		a.order = append(a.order, traceID)
+	}
+	a.items[traceID] = collector
+}
+
+// Get returns the collector saved under the given trace ID.
+func (a *Store) Get(traceID string) (*trace.Collector, bool) {
+	a.locker.RLock()
+	defer a.locker.RUnlock()
+	collector, ok := a.items[traceID]
+	return collector, ok
+}
+
+// makeOptions applies the options on top of the defaults, which deny every request.
+func makeOptions(opts []Option) *options {
+	result := &options{authorize: func(context.Context) bool { return false }}
+	for _, opt := range opts {
+		opt(result)
+	}
+	return result
+}
+
+func (a *options) SetAuthorizer(v func(c context.Context) bool) {
+	if v != nil {
+		a.authorize = v
+	}
+}
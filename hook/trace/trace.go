@@ -0,0 +1,79 @@
+package trace
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// NewCollector creates an empty trace collector.
+func NewCollector() *Collector { return &Collector{} }
+
+// ToContext returns a new context carrying the given collector.
+// Hook providers record every evaluated handler into the collector found in the context.
+func ToContext(c context.Context, collector *Collector) context.Context {
+	return context.WithValue(c, ctxKey{}, collector)
+}
+
+// FromContext returns the collector stored in the context, or nil if tracing is not enabled.
+func FromContext(c context.Context) *Collector {
+	collector, _ := c.Value(ctxKey{}).(*Collector)
+	return collector
+}
+
+type (
+	// Collector accumulates hook execution records of a single traced request.
+	// It is safe for concurrent use.
+	Collector struct {
+		locker  sync.Mutex
+		records []Record
+	}
+
+	// Record describes a single hook handler evaluated by a provider.
+	Record struct {
+		Hook      string        `json:"hook"`                // type of the hooked value (e.g. qb.SelectQuery)
+		PluginID  string        `json:"pluginId"`            // ID of the plugin that registered the handler
+		Kinds     string        `json:"kinds"`               // hook kinds the provider was called with
+		Matched   bool          `json:"matched"`             // filter decision
+		Executed  bool          `json:"executed"`            // whether the matched handler has been called
+		Duration  time.Duration `json:"duration,omitempty"`  // handler execution time in nanoseconds
+		Error     string        `json:"error,omitempty"`     // error returned by the handler
+		SQLBefore *SQL          `json:"sqlBefore,omitempty"` // query before the SQL mutator was applied
+		SQLAfter  *SQL          `json:"sqlAfter,omitempty"`  // query after the SQL mutator was applied
+	}
+
+	// SQL is a rendered query snapshot taken around an SQL mutator.
+	SQL struct {
+		Query string `json:"query,omitempty"`
+		Args  []any  `json:"args,omitempty"`
+		Error string `json:"error,omitempty"`
+	}
+
+	// ctxKey is the context key of the collector.
+	ctxKey struct{}
+)
+
+// Add appends a record and returns its index for later updates.
+func (a *Collector) Add(record Record) int {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	a.records = append(a.records, record)
+	return len(a.records) - 1
+}
+
+// Update modifies the record at the given index (e.g. after the handler has been executed).
+func (a *Collector) Update(index int, update func(record *Record)) {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	if index >= 0 && index < len(a.records) {
+		update(&a.records[index])
+	}
+}
+
+// Records returns a copy of all collected records in evaluation order.
+func (a *Collector) Records() []Record {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	return slices.Clone(a.records)
+}
@@ -2,6 +2,7 @@ package httpserver
 
 import (
 	"context"
+	"io"
 )
 
 type (
@@ -32,6 +33,12 @@ type (
 		//   - any other type: marshaled to JSON before sending.
 		Send(out any) error
 
+		// SendStream sends a streaming response written by the callback.
+		// Data written to the writer is sent to the client on every Flush.
+		// The context passed to the callback is canceled when the client disconnects.
+		// Response headers must be set before calling SendStream.
+		SendStream(c context.Context, cb func(c context.Context, w StreamWriter) error) error
+
 		// ParseParams populates the given struct with values from path parameters.
 		// Returns an error if binding fails.
 		ParseParams(in any) error
@@ -63,4 +70,12 @@ type (
 		// GetIP returns the client's IP address as seen by the server.
 		GetIP() string
 	}
+
+	// StreamWriter is the response body writer of a streaming response.
+	StreamWriter interface {
+		io.Writer
+
+		// Flush sends the buffered data to the client.
+		Flush() error
+	}
 )
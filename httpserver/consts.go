@@ -66,5 +66,7 @@ const (
 	StatusNetworkAuthenticationRequired = 511
 
 	HeaderAuthorization = "Authorization"
+	HeaderCacheControl  = "Cache-Control"
 	HeaderContentType   = "Content-Type"
+	HeaderLastEventID   = "Last-Event-ID"
 )
@@ -1,3 +1,7 @@
 module github.com/hypershadow-io/contract/httpserver
 
 go 1.24.0
+
+require github.com/hypershadow-io/contract/json v1.1.0
+
+require github.com/hypershadow-io/contract/codec v1.0.0 // indirect
@@ -0,0 +1,218 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hypershadow-io/contract/json"
+)
+
+const (
+	// SSEContentType is the content type of Server-Sent Events streams.
+	SSEContentType = "text/event-stream"
+
+	// DefaultSSEHeartbeat is the default interval of heartbeat comments keeping idle streams open through proxies.
+	DefaultSSEHeartbeat = 15 * time.Second
+)
+
+// HandleSSE creates a request handler streaming Server-Sent Events written by the callback.
+// The stream ends when the callback returns or the client disconnects; in the latter case
+// the context passed to the callback is canceled and SSEWriter.Send returns the context error.
+//
+// Example:
+//
+//	router.Get("/runs/:id/events", httpserver.HandleSSE(client, func(c context.Context, w httpserver.SSEWriter) error {
+//		for progress := range run.Progress(c, w.LastEventID()) {
+//			if err := w.Send(httpserver.SSEEvent{ID: progress.ID, Event: "progress", Data: progress}); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	}))
+func HandleSSE(
+	client Client,
+	cb func(c context.Context, w SSEWriter) error,
+	opts ...SSEOption,
+) Handler {
+	return func(c context.Context) error {
+		return StreamSSE(c, client.CtxFromContext(c), cb, opts...)
+	}
+}
+
+// StreamSSE sets the Server-Sent Events response headers and streams the events written by the callback.
+// Heartbeat comments are sent periodically to keep the connection open (see WithSSEHeartbeat).
+func StreamSSE(
+	c context.Context,
+	ctx Ctx,
+	cb func(c context.Context, w SSEWriter) error,
+	opts ...SSEOption,
+) error {
+	o := &sseOptions{heartbeat: DefaultSSEHeartbeat}
+	for _, opt := range opts {
+		opt(o)
+	}
+	ctx.SetHeader(HeaderContentType, SSEContentType)
+	ctx.SetHeader(HeaderCacheControl, "no-cache")
+	ctx.SetHeader("X-Accel-Buffering", "no") // disables response buffering of nginx
+	lastEventID := ctx.GetHeader(HeaderLastEventID)
+	return ctx.SendStream(c, func(c context.Context, w StreamWriter) error {
+		c, cancel := context.WithCancel(c)
+		writer := &sseWriter{c: c, w: w, lastEventID: lastEventID}
+		var wg sync.WaitGroup
+		defer func() {
+			cancel()
+			wg.Wait()
+		}()
+		if o.retry > 0 {
+			if err := writer.write([]byte("retry: " + strconv.FormatInt(o.retry.Milliseconds(), 10) + "\n\n")); err != nil {
+				return err
+			}
+		} else if err := writer.write(nil); err != nil {
+			return err
+		}
+		if o.heartbeat > 0 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				writer.heartbeat(o.heartbeat)
+			}()
+		}
+		return cb(c, writer)
+	})
+}
+
+// WithSSEHeartbeat sets the interval of heartbeat comments (DefaultSSEHeartbeat by default; zero disables them).
+func WithSSEHeartbeat(v time.Duration) SSEOption {
+	return func(opt sseOption) { opt.SetHeartbeat(v) }
+}
+
+// WithSSERetry sends the reconnection delay hint to the client when the stream starts.
+func WithSSERetry(v time.Duration) SSEOption {
+	return func(opt sseOption) { opt.SetRetry(v) }
+}
+
+type (
+	// SSEWriter writes Server-Sent Events to the client.
+	// It is safe for concurrent use.
+	SSEWriter interface {
+		// Send writes the event and flushes it to the client.
+		// Returns the context error once the client has disconnected.
+		Send(event SSEEvent) error
+
+		// LastEventID returns the ID of the last event the client received before reconnecting
+		// (the Last-Event-ID request header), or an empty string for a new stream.
+		// Use it to resume the stream from the next event.
+		LastEventID() string
+	}
+
+	// SSEEvent is a single Server-Sent Event.
+	SSEEvent struct {
+		ID    string        // event ID, reported back by the client as Last-Event-ID after reconnection
+		Event string        // event type; the client dispatches untyped events as "message"
+		Data  any           // payload: string and []byte are sent as is, other values are marshaled to JSON
+		Retry time.Duration // reconnection delay hint; zero keeps the current one
+	}
+
+	// SSEOption defines a function used to configure Server-Sent Events streams.
+	SSEOption func(sseOption)
+
+	// sseOption defines internal configuration methods for stream options.
+	sseOption interface {
+		// SetHeartbeat sets the interval of heartbeat comments.
+		SetHeartbeat(time.Duration)
+
+		// SetRetry sets the initial reconnection delay hint.
+		SetRetry(time.Duration)
+	}
+
+	// sseOptions holds stream settings.
+	sseOptions struct {
+		heartbeat time.Duration
+		retry     time.Duration
+	}
+
+	// sseWriter is the SSEWriter implementation over a stream writer.
+	sseWriter struct {
+		c           context.Context
+		locker      sync.Mutex
+		w           StreamWriter
+		lastEventID string
+	}
+)
+
+// sseFieldReplacer removes line breaks, which would end a field of the event.
+var sseFieldReplacer = strings.NewReplacer("\r", "", "\n", "")
+
+func (a *sseWriter) Send(event SSEEvent) error {
+	var data []byte
+	switch v := event.Data.(type) {
+	case nil:
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return err
+		}
+	}
+	var buffer bytes.Buffer
+	if event.ID != "" {
+		buffer.WriteString("id: " + sseFieldReplacer.Replace(event.ID) + "\n")
+	}
+	if event.Event != "" {
+		buffer.WriteString("event: " + sseFieldReplacer.Replace(event.Event) + "\n")
+	}
+	if event.Retry > 0 {
+		buffer.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	data = bytes.ReplaceAll(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")), []byte("\r"), []byte("\n"))
+	for line := range bytes.SplitSeq(data, []byte("\n")) {
+		buffer.WriteString("data: ")
+		buffer.Write(line)
+		buffer.WriteByte('\n')
+	}
+	buffer.WriteByte('\n')
+	return a.write(buffer.Bytes())
+}
+
+func (a *sseWriter) LastEventID() string { return a.lastEventID }
+
+// write writes the data and flushes it, unless the client has disconnected.
+func (a *sseWriter) write(data []byte) error {
+	a.locker.Lock()
+	defer a.locker.Unlock()
+	if err := a.c.Err(); err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		if _, err := a.w.Write(data); err != nil {
+			return err
+		}
+	}
+	return a.w.Flush()
+}
+
+// heartbeat sends comments at the interval until the stream ends.
+func (a *sseWriter) heartbeat(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.c.Done():
+			return
+		case <-ticker.C:
+			if a.write([]byte(":\n\n")) != nil {
+				return
+			}
+		}
+	}
+}
+
+func (a *sseOptions) SetHeartbeat(v time.Duration) { a.heartbeat = v }
+func (a *sseOptions) SetRetry(v time.Duration)     { a.retry = v }
@@ -0,0 +1,17 @@
+package hook
+
+import (
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/hook/expr"
+	"github.com/hypershadow-io/contract/integration/model"
+)
+
+// Fields lists the fields of integration models available in declarative hook filter expressions.
+var Fields = expr.Fields[model.Model]{
+	"id":            expr.Field(model.Model.GetID),
+	"title":         expr.Field(model.Model.GetTitle),
+	"definitionKey": expr.Field(model.Model.GetDefinitionKey),
+}
+
+// CompileFilter compiles a declarative filter expression into a hook filter for integration models.
+func CompileFilter(e expr.Expr) (hook.Filter[model.Model], error) { return expr.Compile(e, Fields) }
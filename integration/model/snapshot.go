@@ -0,0 +1,21 @@
+package model
+
+import "github.com/hypershadow-io/contract/meta"
+
+// Snapshot defines the serializable state of the integration.
+type Snapshot struct {
+	ID            int64     `json:"id"`
+	Title         string    `json:"title"`
+	DefinitionKey string    `json:"definitionKey"`
+	Meta          meta.Meta `json:"meta,omitempty"`
+}
+
+// NewSnapshot copies the integration into a Snapshot.
+func NewSnapshot(m Model) Snapshot {
+	return Snapshot{
+		ID:            m.GetID(),
+		Title:         m.GetTitle(),
+		DefinitionKey: m.GetDefinitionKey(),
+		Meta:          m.GetMeta(),
+	}
+}
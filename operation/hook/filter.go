@@ -0,0 +1,21 @@
+package hook
+
+import (
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/hook/expr"
+	"github.com/hypershadow-io/contract/operation/model"
+)
+
+// Fields lists the fields of operation models available in declarative hook filter expressions.
+var Fields = expr.Fields[model.Model]{
+	"id":            expr.Field(model.Model.GetID),
+	"integrationId": expr.Field(model.Model.GetIntegrationID),
+	"title":         expr.Field(model.Model.GetTitle),
+	"action":        expr.Field(model.Model.GetAction),
+	"externalId":    expr.Field(model.Model.GetExternalID),
+	"dispatcherKey": expr.Field(model.Model.GetDispatcherKey),
+	"locked":        expr.Field(model.Model.IsLocked),
+}
+
+// CompileFilter compiles a declarative filter expression into a hook filter for operation models.
+func CompileFilter(e expr.Expr) (hook.Filter[model.Model], error) { return expr.Compile(e, Fields) }
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/hypershadow-io/contract/meta"
+)
+
+// Snapshot defines the serializable state of the operation.
+type Snapshot struct {
+	ID            int64     `json:"id"`
+	IntegrationID int64     `json:"integrationId"`
+	Title         string    `json:"title"`
+	Action        string    `json:"action"`
+	ExternalID    string    `json:"externalId"`
+	DispatcherKey string    `json:"dispatcherKey"`
+	Locked        bool      `json:"locked"`
+	ModifiedAt    time.Time `json:"modifiedAt"`
+	Meta          meta.Meta `json:"meta,omitempty"`
+}
+
+// NewSnapshot copies the operation into a Snapshot.
+func NewSnapshot(m Model) Snapshot {
+	return Snapshot{
+		ID:            m.GetID(),
+		IntegrationID: m.GetIntegrationID(),
+		Title:         m.GetTitle(),
+		Action:        m.GetAction(),
+		ExternalID:    m.GetExternalID(),
+		DispatcherKey: m.GetDispatcherKey(),
+		Locked:        m.IsLocked(),
+		ModifiedAt:    m.GetModifiedAt(),
+		Meta:          m.GetMeta(),
+	}
+}
@@ -0,0 +1,196 @@
+package guard
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+
+	"github.com/hypershadow-io/contract/db"
+	"github.com/hypershadow-io/contract/dbhook"
+	"github.com/hypershadow-io/contract/eb"
+	ebimpl "github.com/hypershadow-io/contract/eb/impl"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/httpserver"
+	orgctx "github.com/hypershadow-io/contract/organization/ctx"
+	"github.com/hypershadow-io/contract/qb"
+)
+
+// DefaultColumn is the default name of the column holding the organization ID.
+const DefaultColumn = "organization_id"
+
+// KeyOrganizationRequired is the error key attached to queries run without an organization in the context.
+const KeyOrganizationRequired = "organization.guard.organization_required"
+
+// New creates a tenant guard for tables shared across organizations on the platform DB.
+// The guard scopes the query to the organization from the context and runs dbhook mutators:
+//   - SELECT, UPDATE and DELETE queries get the organization predicate before the mutators run,
+//     so queries derived by the mutators (e.g. the soft deletion UPDATE) stay scoped;
+//   - INSERT queries get the organization column stamped before the mutators run,
+//     and overridden after them, so mutators cannot move rows to another organization.
+//
+// If the context has no organization, the query fails with KeyOrganizationRequired,
+// unless hook.KindSystem is present: such a query runs unscoped and is logged as a bypass.
+func New(
+	orgClient orgctx.Client,
+	builder qb.QueryBuilder,
+	opts ...Option,
+) *Guard {
+	o := &options{
+		column: DefaultColumn,
+		logger: slog.WarnContext,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Guard{
+		orgClient: orgClient,
+		builder:   builder,
+		options:   *o,
+	}
+}
+
+// WithColumn sets the name of the organization column (DefaultColumn by default).
+// Use a qualified name (e.g. "t.organization_id") if queries use table aliases.
+func WithColumn(v string) Option {
+	return func(o option) { o.SetColumn(v) }
+}
+
+// WithLogger sets the logger for queries bypassing the guard (slog.WarnContext by default).
+func WithLogger(v eb.LogFunc) Option {
+	return func(o option) { o.SetLogger(v) }
+}
+
+type (
+	// Guard enforces row-level organization isolation on queries to shared tables.
+	Guard struct {
+		options   options
+		orgClient orgctx.Client
+		builder   qb.QueryBuilder
+	}
+
+	// ErrOrganizationRequired is the base error of a query run without an organization in the context.
+	ErrOrganizationRequired struct{}
+
+	// Option defines a functional option for the guard.
+	Option func(option)
+
+	// option is an internal interface used to apply configuration.
+	option interface {
+		// SetColumn sets the name of the organization column.
+		SetColumn(string)
+
+		// SetLogger sets the logger for bypassing queries.
+		SetLogger(eb.LogFunc)
+	}
+
+	// options holds guard settings.
+	options struct {
+		column string
+		logger eb.LogFunc
+	}
+)
+
+func (ErrOrganizationRequired) Error() string { return "organization required" }
+
+// Select scopes a SELECT query to the organization and applies mutation hooks to it.
+func (a *Guard) Select(
+	c context.Context, kinds hook.Kinds, provider dbhook.Provider[qb.SelectQuery], value qb.SelectQuery,
+) qb.SelectQuery {
+	value, err := scope(a, c, kinds, value)
+	if err != nil {
+		return value.SetError(err)
+	}
+	return dbhook.Select(c, kinds, provider, value)
+}
+
+// Insert builds an INSERT query from the clauses, stamps the organization column,
+// and applies mutation hooks to it. The organization column is overridden after the hooks.
+func (a *Guard) Insert(
+	c context.Context, kinds hook.Kinds, provider dbhook.Provider[qb.InsertQuery], table string, clauses map[string]any,
+) qb.InsertQuery {
+	value := a.builder.Insert(table).SetMap(clauses)
+	organizationID, err := a.organization(c, kinds, value)
+	if err != nil {
+		return value.SetError(err)
+	}
+	if organizationID == 0 {
+		return dbhook.Insert(c, kinds, provider, value)
+	}
+	clauses = maps.Clone(clauses)
+	if clauses == nil {
+		clauses = make(map[string]any, 1)
+	}
+	clauses[a.options.column] = organizationID
+	value = dbhook.Insert(c, kinds, provider, value.SetMap(clauses))
+	return value.Override(a.options.column, organizationID)
+}
+
+// Update scopes an UPDATE query to the organization and applies mutation hooks to it.
+func (a *Guard) Update(
+	c context.Context, kinds hook.Kinds, provider dbhook.Provider[qb.UpdateQuery], value qb.UpdateQuery,
+) qb.UpdateQuery {
+	value, err := scope(a, c, kinds, value)
+	if err != nil {
+		return value.SetError(err)
+	}
+	return dbhook.Update(c, kinds, provider, value)
+}
+
+// Delete scopes a DELETE query to the organization and applies mutation hooks to it.
+// A skip or a replacement by another query type (e.g. the soft deletion UPDATE) fails the query
+// like dbhook.Delete; use ApplyDelete to perform the replacement of entities with soft deletion.
+func (a *Guard) Delete(
+	c context.Context, kinds hook.Kinds, provider dbhook.Provider[qb.DeleteQuery], value qb.DeleteQuery,
+) qb.DeleteQuery {
+	value, err := scope(a, c, kinds, value)
+	if err != nil {
+		return value.SetError(err)
+	}
+	return dbhook.Delete(c, kinds, provider, value)
+}
+
+// ApplyDelete scopes a DELETE query to the organization and applies mutation hooks to it like dbhook.Apply,
+// reporting the control action of the chain. A replacement (e.g. the soft deletion UPDATE)
+// is derived from the scoped query, so it is scoped as well.
+func (a *Guard) ApplyDelete(
+	c context.Context, kinds hook.Kinds, provider dbhook.Provider[qb.DeleteQuery], value qb.DeleteQuery,
+) hook.Result[qb.DeleteQuery] {
+	value, err := scope(a, c, kinds, value)
+	if err != nil {
+		return hook.Result[qb.DeleteQuery]{Value: value.SetError(err)}
+	}
+	return dbhook.Apply(c, kinds, provider, value)
+}
+
+// organization returns the organization ID from the context.
+// Returns zero without an error for a system query run without an organization, logging the bypass.
+func (a *Guard) organization(c context.Context, kinds hook.Kinds, query db.Query) (int64, error) {
+	if organizationID := a.orgClient.IDFromContext(c); organizationID != 0 {
+		return organizationID, nil
+	}
+	if kinds.Not(hook.KindSystem) {
+		return 0, ebimpl.Make[ErrOrganizationRequired]().
+			SetKey(KeyOrganizationRequired).
+			SetCode(httpserver.StatusForbidden).
+			SetMessagef("organization required")
+	}
+	sql, _, _ := query.ToSql()
+	a.options.logger(c, "organization guard bypassed", "kinds", kinds.String(), "sql", sql)
+	return 0, nil
+}
+
+// scope adds the organization predicate to the query.
+// The query is returned as is for a system query run without an organization.
+func scope[T interface {
+	db.Query
+	AndWhere(db.Query) T
+}](a *Guard, c context.Context, kinds hook.Kinds, value T) (T, error) {
+	organizationID, err := a.organization(c, kinds, value)
+	if err != nil || organizationID == 0 {
+		return value, err
+	}
+	return value.AndWhere(a.builder.Eq(map[string]any{a.options.column: organizationID})), nil
+}
+
+func (a *options) SetColumn(v string)     { a.column = v }
+func (a *options) SetLogger(v eb.LogFunc) { a.logger = v }
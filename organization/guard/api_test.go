@@ -0,0 +1,239 @@
+package guard_test
+
+import (
+	"context"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hypershadow-io/contract/db"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/organization/guard"
+	"github.com/hypershadow-io/contract/qb"
+	"github.com/hypershadow-io/contract/softdelete"
+)
+
+type (
+	orgClient struct{}
+	orgKey    struct{}
+
+	// expr is a rendered SQL fragment.
+	expr struct {
+		sql  string
+		args []any
+	}
+
+	// builder implements the part of qb.QueryBuilder used by the guard and soft deletion.
+	builder struct{ qb.QueryBuilder }
+
+	// query renders simplified SQL of the fake query builders.
+	query struct {
+		verb  string
+		table string
+		set   []expr
+		where []expr
+		err   error
+	}
+
+	selectQuery struct {
+		qb.SelectQuery
+		query
+	}
+
+	updateQuery struct {
+		qb.UpdateQuery
+		query
+	}
+
+	deleteQuery struct {
+		qb.DeleteQuery
+		query
+	}
+
+	// hooks is a single-plugin hook client serving both as a registry and a provider.
+	hooks[T any] struct {
+		filters  []hook.Filter[T]
+		handlers []hook.MutatorFunc[T]
+	}
+
+	registration[T any] struct{ *hooks[T] }
+
+	client struct {
+		sel *hooks[qb.SelectQuery]
+		del *hooks[qb.DeleteQuery]
+	}
+)
+
+func (orgClient) IDFromContext(c context.Context) int64 {
+	id, _ := c.Value(orgKey{}).(int64)
+	return id
+}
+func (orgClient) IDToContext(c context.Context, id int64) context.Context {
+	return context.WithValue(c, orgKey{}, id)
+}
+
+func (a expr) ToSql() (string, []any, error) { return a.sql, a.args, nil }
+
+func (builder) Sql(sql string, args ...any) db.Query { return expr{sql, args} }
+func (builder) Eq(v map[string]any) db.Query {
+	var list []string
+	var args []any
+	for _, k := range slices.Sorted(maps.Keys(v)) {
+		if v[k] == nil {
+			list = append(list, k+" IS NULL")
+			continue
+		}
+		list = append(list, k+" = ?")
+		args = append(args, v[k])
+	}
+	return expr{strings.Join(list, " AND "), args}
+}
+
+func (a query) ToSql() (string, []any, error) {
+	if a.err != nil {
+		return "", nil, a.err
+	}
+	sql, args := a.verb+" "+a.table, []any(nil)
+	for i, e := range a.set {
+		sql += map[bool]string{true: " SET ", false: ", "}[i == 0] + e.sql
+		args = append(args, e.args...)
+	}
+	for i, e := range a.where {
+		sql += map[bool]string{true: " WHERE ", false: " AND "}[i == 0] + e.sql
+		args = append(args, e.args...)
+	}
+	return sql, args, nil
+}
+
+// and returns a copy of the query with the condition added.
+func (a query) and(q db.Query) query {
+	sql, args, _ := q.ToSql()
+	a.where = append(slices.Clone(a.where), expr{sql, args})
+	return a
+}
+
+func (a selectQuery) ToSql() (string, []any, error)      { return a.query.ToSql() }
+func (a selectQuery) AndWhere(q db.Query) qb.SelectQuery { return selectQuery{query: a.and(q)} }
+func (a selectQuery) SetError(err error) qb.SelectQuery  { a.err = err; return a }
+func (a updateQuery) ToSql() (string, []any, error)      { return a.query.ToSql() }
+func (a updateQuery) AndWhere(q db.Query) qb.UpdateQuery { return updateQuery{query: a.and(q)} }
+func (a updateQuery) SetError(err error) qb.UpdateQuery  { a.err = err; return a }
+func (a deleteQuery) ToSql() (string, []any, error)      { return a.query.ToSql() }
+func (a deleteQuery) AndWhere(q db.Query) qb.DeleteQuery { return deleteQuery{query: a.and(q)} }
+func (a deleteQuery) SetError(err error) qb.DeleteQuery  { a.err = err; return a }
+func (a deleteQuery) ToSelect(...string) qb.SelectQuery {
+	a.verb = "SELECT * FROM"
+	return selectQuery{query: a.query}
+}
+func (a updateQuery) Set(column string, value any) qb.UpdateQuery {
+	e := expr{column + " = ?", []any{value}}
+	if q, ok := value.(db.Query); ok {
+		sql, args, _ := q.ToSql()
+		e = expr{column + " = " + sql, args}
+	}
+	a.set = append(slices.Clone(a.set), e)
+	return a
+}
+func (a deleteQuery) ToUpdate() qb.UpdateQuery {
+	a.verb = "UPDATE"
+	return updateQuery{query: a.query}
+}
+
+func (a *hooks[T]) Add(filter hook.Filter[T], handler hook.MutatorFunc[T]) hook.Registration[hook.MutatorFunc[T], T] {
+	a.filters = append(a.filters, filter)
+	a.handlers = append(a.handlers, handler)
+	return registration[T]{a}
+}
+
+func (a *hooks[T]) Find(c context.Context, kinds hook.Kinds, value T) iter.Seq[hook.MutatorFunc[T]] {
+	return func(yield func(hook.MutatorFunc[T]) bool) {
+		for i, handler := range a.handlers {
+			if (a.filters[i] == nil || a.filters[i](c, kinds, value)) && !yield(handler) {
+				return
+			}
+		}
+	}
+}
+
+func (registration[T]) Remove() bool { return true }
+
+func (a client) SQLSelectHook(string) hook.Mutator[qb.SelectQuery] { return a.sel }
+func (a client) SQLDeleteHook(string) hook.Mutator[qb.DeleteQuery] { return a.del }
+
+func TestGuard_SoftDelete(t *testing.T) {
+	hc := client{sel: &hooks[qb.SelectQuery]{}, del: &hooks[qb.DeleteQuery]{}}
+	softdelete.Register("softdelete", hc, builder{}, orgClient{}, softdelete.Target{Table: "agents", IDColumn: "id"})
+	g := guard.New(orgClient{}, builder{})
+	c := orgClient{}.IDToContext(context.Background(), 42)
+
+	tests := []struct {
+		name string
+		run  func() (db.Query, hook.Action)
+		want string
+	}{
+		{
+			name: "select",
+			run: func() (db.Query, hook.Action) {
+				value := selectQuery{query: query{verb: "SELECT * FROM", table: "agents"}}
+				return g.Select(c, hook.NewKinds(), hc.sel, value), hook.ActionNone
+			},
+			want: "SELECT * FROM agents WHERE organization_id = ? AND deleted_at IS NULL",
+		},
+		{
+			name: "soft delete",
+			run: func() (db.Query, hook.Action) {
+				value := deleteQuery{query: query{verb: "DELETE FROM", table: "agents", where: []expr{{"id = ?", []any{1}}}}}
+				result := g.ApplyDelete(c, hook.NewKinds(hook.KindDelete), hc.del, value)
+				replacement, _ := result.Replacement.(db.Query)
+				return replacement, result.Action
+			},
+			want: "UPDATE agents SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND organization_id = ? AND deleted_at IS NULL",
+		},
+		{
+			name: "hard delete",
+			run: func() (db.Query, hook.Action) {
+				value := deleteQuery{query: query{verb: "DELETE FROM", table: "agents", where: []expr{{"id = ?", []any{1}}}}}
+				result := g.ApplyDelete(c, hook.NewKinds(hook.KindDelete, softdelete.KindHardDelete), hc.del, value)
+				return result.Value, result.Action
+			},
+			want: "DELETE FROM agents WHERE id = ? AND organization_id = ?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, action := tt.run()
+			if got == nil {
+				t.Fatalf("no query, action %v", action)
+			}
+			sql, args, err := got.ToSql()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != tt.want {
+				t.Errorf("sql = %q, want %q", sql, tt.want)
+			}
+			if !slices.Contains(args, any(int64(42))) {
+				t.Errorf("args = %v, want the organization ID", args)
+			}
+		})
+	}
+}
+
+func TestGuard_DeleteFailsClosed(t *testing.T) {
+	hc := client{sel: &hooks[qb.SelectQuery]{}, del: &hooks[qb.DeleteQuery]{}}
+	softdelete.Register("softdelete", hc, builder{}, orgClient{}, softdelete.Target{Table: "agents", IDColumn: "id"})
+	g := guard.New(orgClient{}, builder{})
+	c := orgClient{}.IDToContext(context.Background(), 42)
+
+	value := deleteQuery{query: query{verb: "DELETE FROM", table: "agents", where: []expr{{"id = ?", []any{1}}}}}
+	_, _, err := g.Delete(c, hook.NewKinds(hook.KindDelete), hc.del, value).ToSql()
+	signal, ok := hook.AsSignal(err)
+	if !ok || signal.Action() != hook.ActionReplace {
+		t.Fatalf("error = %v, want the soft deletion replacement signal", err)
+	}
+	if _, ok = signal.Replacement().(qb.UpdateQuery); !ok {
+		t.Errorf("replacement = %T, want the soft deletion UPDATE", signal.Replacement())
+	}
+}
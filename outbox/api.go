@@ -0,0 +1,126 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/hypershadow-io/contract/entity"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/runner"
+)
+
+type (
+	// Client defines the transactional outbox interface.
+	// Events are persisted in the outbox table together with the business write,
+	// and are delivered to consumers only after the surrounding transaction is committed.
+	// Delivery is at-least-once: consumers must be idempotent and use Message.GetID for deduplication.
+	Client interface {
+		// Publish stores an event in the outbox table using the DB transaction from the given context.
+		// If the transaction is rolled back, the event is discarded together with the write.
+		// The payload is serialized to JSON.
+		Publish(
+			c context.Context,
+			entityType entity.Type,
+			entityID int64,
+			kinds hook.Kinds,
+			payload any,
+		) error
+
+		// Consumer returns an event registry for outbox messages of the given plugin consumer.
+		// Each consumer key has its own checkpoint: a message is acknowledged for the consumer
+		// only after all of its matching handlers succeed, otherwise it is retried.
+		Consumer(pluginID string, consumerKey string, opts ...ConsumerOption) hook.Event[Message]
+
+		// Relay returns the command that reads committed messages and delivers them to the consumers.
+		// It should be registered once in the runner.
+		Relay(opts ...RelayOption) runner.Command
+	}
+
+	// Message represents a single persisted outbox event.
+	Message interface {
+		// GetID returns the unique, monotonically increasing identifier of the message.
+		GetID() int64
+
+		// GetOrganizationID returns the ID of the organization in which the event occurred.
+		GetOrganizationID() int64
+
+		// GetEntityType returns the type of the entity the event relates to.
+		GetEntityType() entity.Type
+
+		// GetEntityID returns the ID of the entity the event relates to.
+		GetEntityID() int64
+
+		// GetKinds returns the hook kinds the event was published with.
+		GetKinds() hook.Kinds
+
+		// GetPayload returns the JSON-encoded event payload.
+		GetPayload() []byte
+
+		// GetAttempt returns the delivery attempt number for the current consumer, starting at 1.
+		GetAttempt() int
+
+		// GetCreatedAt returns the time the event was published.
+		GetCreatedAt() time.Time
+	}
+
+	// ConsumerOption defines a function used to configure consumer delivery behavior.
+	ConsumerOption func(consumerOption)
+
+	// consumerOption defines internal configuration methods for consumer options.
+	consumerOption interface {
+		// SetMaxAttempts sets the number of delivery attempts before the message is marked as dead
+		// and the consumer checkpoint moves forward.
+		SetMaxAttempts(int)
+
+		// SetBackoff sets the initial delay between retries, doubled on every further attempt.
+		SetBackoff(time.Duration)
+
+		// SetOnDead registers a callback invoked when a message exhausts all delivery attempts.
+		SetOnDead(func(c context.Context, message Message, err error))
+	}
+
+	// RelayOption defines a function used to configure the relay command.
+	RelayOption func(relayOption)
+
+	// relayOption defines internal configuration methods for relay options.
+	relayOption interface {
+		// SetPollInterval sets how often the relay checks the outbox table for new messages.
+		SetPollInterval(time.Duration)
+
+		// SetBatchSize sets the maximum number of messages read per consumer at once.
+		SetBatchSize(int)
+
+		// SetRetention sets how long messages acknowledged by all consumers are kept before cleanup.
+		SetRetention(time.Duration)
+	}
+)
+
+// WithConsumerMaxAttempts returns a ConsumerOption that limits the number of delivery attempts.
+func WithConsumerMaxAttempts(v int) ConsumerOption {
+	return func(opt consumerOption) { opt.SetMaxAttempts(v) }
+}
+
+// WithConsumerBackoff returns a ConsumerOption that sets the initial delay between retries.
+func WithConsumerBackoff(v time.Duration) ConsumerOption {
+	return func(opt consumerOption) { opt.SetBackoff(v) }
+}
+
+// WithConsumerOnDead returns a ConsumerOption that registers a callback for undeliverable messages.
+func WithConsumerOnDead(v func(c context.Context, message Message, err error)) ConsumerOption {
+	return func(opt consumerOption) { opt.SetOnDead(v) }
+}
+
+// WithRelayPollInterval returns a RelayOption that sets the polling interval.
+func WithRelayPollInterval(v time.Duration) RelayOption {
+	return func(opt relayOption) { opt.SetPollInterval(v) }
+}
+
+// WithRelayBatchSize returns a RelayOption that sets the read batch size.
+func WithRelayBatchSize(v int) RelayOption {
+	return func(opt relayOption) { opt.SetBatchSize(v) }
+}
+
+// WithRelayRetention returns a RelayOption that sets the retention of acknowledged messages.
+func WithRelayRetention(v time.Duration) RelayOption {
+	return func(opt relayOption) { opt.SetRetention(v) }
+}
@@ -0,0 +1,54 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/hypershadow-io/contract/entity"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/json"
+)
+
+// Forward returns an event hook that publishes a snapshot of every received value of type T into the outbox.
+// Registering it in a ModelEvent registry (agent, operation, integration, tokens)
+// makes model events durable, as long as the registry is triggered inside the write transaction.
+//
+// Models are interfaces and cannot be decoded, so the payload is the concrete snapshot S
+// returned by the snapshot function (e.g. the Snapshot of the model package, which copies
+// the state of the model into a struct that survives the round trip); consumers decode it with Decode[S].
+//
+// Example:
+//
+//	events.Add(nil, outbox.Forward(client, agent.EntityType, agentmodel.Model.GetID, agentmodel.NewSnapshot))
+func Forward[T, S any](
+	client Client,
+	entityType entity.Type,
+	getEntityID func(value T) int64,
+	snapshot func(value T) S,
+) hook.EventFunc[T] {
+	return func(c context.Context, kinds hook.Kinds, value T) error {
+		return client.Publish(c, entityType, getEntityID(value), kinds, snapshot(value))
+	}
+}
+
+// Decode unmarshals the message payload into a value of the concrete type T (e.g. agentmodel.Snapshot).
+// Returns ErrInterfacePayload if T is an interface type, which cannot be decoded into.
+func Decode[T any](message Message) (T, error) {
+	var result T
+	if reflect.TypeFor[T]().Kind() == reflect.Interface {
+		return result, ErrInterfacePayload
+	}
+	err := json.Unmarshal(message.GetPayload(), &result)
+	return result, err
+}
+
+// ErrInterfacePayload is returned by Decode when the payload is requested as an interface type.
+var ErrInterfacePayload = errors.New("outbox: payload cannot be decoded into an interface type")
+
+// MatchEntityType returns a filter that matches only messages related to the given entity type.
+func MatchEntityType(entityType entity.Type) hook.Filter[Message] {
+	return func(_ context.Context, _ hook.Kinds, message Message) bool {
+		return message.GetEntityType() == entityType
+	}
+}
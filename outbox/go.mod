@@ -0,0 +1,12 @@
+module github.com/hypershadow-io/contract/outbox
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/entity v1.0.0
+	github.com/hypershadow-io/contract/hook v1.0.0
+	github.com/hypershadow-io/contract/json v1.1.0
+	github.com/hypershadow-io/contract/runner v1.0.0
+)
+
+require github.com/hypershadow-io/contract/codec v1.0.0 // indirect
@@ -239,6 +239,14 @@ type (
 		// Select set Select clause for insert query
 		// If Values and Select are used, then Select has higher priority
 		Select(sb SelectQuery) InsertQuery
+
+		// Override sets the value of the column in every row, adding the column if it is missing
+		// and replacing the value set by previous clauses otherwise.
+		// Queries using Select get an error attached, since their values cannot be replaced.
+		//
+		// Example (tenant stamping):
+		//  .Override("organization_id", organizationID)
+		Override(column string, value any) InsertQuery
 	}
 
 	// UpdateQuery defines the interface for building UPDATE SQL queries.
@@ -334,6 +342,22 @@ type (
 
 		// SuffixQuery adds an expression to the end of the query
 		SuffixQuery(query db.Query) DeleteQuery
+
+		// ToUpdate converts the query into an UpdateQuery of the same table,
+		// keeping the prefix, WHERE, ORDER BY, LIMIT, OFFSET and suffix clauses
+		// and the attached error, if any. SET clauses must be added by the caller.
+		//
+		// Example (soft deletion):
+		//  .ToUpdate().Set("deleted_at", qb.Sql("CURRENT_TIMESTAMP"))
+		ToUpdate() UpdateQuery
+
+		// ToSelect converts the query into a SelectQuery of the given columns from the same table,
+		// keeping the WHERE, ORDER BY, LIMIT and OFFSET clauses and the attached error, if any.
+		// Useful to read the rows before they are deleted.
+		//
+		// Example (archiving):
+		//  .ToSelect("*")
+		ToSelect(columns ...string) SelectQuery
 	}
 
 	// CaseQuery defines the interface for building SQL CASE expressions.
@@ -0,0 +1,224 @@
+package softdelete
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hypershadow-io/contract/archive"
+	"github.com/hypershadow-io/contract/db"
+	ebimpl "github.com/hypershadow-io/contract/eb/impl"
+	"github.com/hypershadow-io/contract/hook"
+	"github.com/hypershadow-io/contract/httpserver"
+	orgctx "github.com/hypershadow-io/contract/organization/ctx"
+	"github.com/hypershadow-io/contract/qb"
+)
+
+// Kinds controlling soft deletion.
+const (
+	// KindWithDeleted includes soft-deleted rows into SELECT queries.
+	KindWithDeleted hook.Kind = "WithDeleted"
+
+	// KindHardDelete keeps DELETE queries as is, physically removing the rows.
+	KindHardDelete hook.Kind = "HardDelete"
+)
+
+// Defaults of the soft deletion columns.
+const (
+	// DefaultColumn is the default name of the column holding the deletion time.
+	DefaultColumn = "deleted_at"
+
+	// DefaultOrganizationColumn is the default name of the column holding the organization ID.
+	DefaultOrganizationColumn = "organization_id"
+)
+
+// KeyOrganizationRequired is the error key returned when an entity is restored without an organization in the context.
+const KeyOrganizationRequired = "softdelete.organization_required"
+
+// Register enables soft deletion for the entity served by the hook client
+// (e.g. agent/hook.Client, operation/hook.Client, integration/hook.Client):
+//   - DELETE queries are replaced with UPDATE queries setting the deletion column (see hook.Replace),
+//     unless KindHardDelete is present; if the target has an archive instance, the rows are archived
+//     for the organization of the context before the replacement is returned;
+//   - SELECT queries are filtered to rows with an empty deletion column,
+//     unless KindWithDeleted is present.
+//
+// The replacement is reported only by dbhook.Apply (or organization/guard.Guard.ApplyDelete):
+// the storage of the entity must execute Result.Replacement instead of the DELETE query.
+// Hooks are registered under the given plugin ID and can be unregistered using the returned Registration.
+func Register(
+	pluginID string,
+	client Client,
+	builder qb.QueryBuilder,
+	orgClient orgctx.Client,
+	target Target,
+	opts ...Option,
+) Registration {
+	o := newOption(builder, opts)
+	notDeleted := builder.Eq(map[string]any{o.column: nil})
+	return Registration{
+		sel: client.SQLSelectHook(pluginID).Add(
+			func(_ context.Context, kinds hook.Kinds, _ qb.SelectQuery) bool { return kinds.Not(KindWithDeleted) },
+			func(_ context.Context, _ hook.Kinds, value qb.SelectQuery) (qb.SelectQuery, error) {
+				return value.AndWhere(notDeleted), nil
+			},
+		),
+		del: client.SQLDeleteHook(pluginID).Add(
+			func(_ context.Context, kinds hook.Kinds, _ qb.DeleteQuery) bool { return kinds.Not(KindHardDelete) },
+			func(c context.Context, _ hook.Kinds, value qb.DeleteQuery) (qb.DeleteQuery, error) {
+				if target.Archive != nil {
+					rows := target.Archive.AddMany(c, nil, orgClient.IDFromContext(c), value.ToSelect("*").AndWhere(notDeleted))
+					for _, err := range rows {
+						if err != nil {
+							return value, err
+						}
+					}
+				}
+				return value, hook.Replace(value.ToUpdate().
+					Set(o.setColumn(), o.value).
+					AndWhere(notDeleted))
+			},
+		),
+	}
+}
+
+// Restore clears the deletion mark of a soft-deleted entity of the organization from the context.
+// Returns found = false if the entity does not exist, is not deleted, or belongs to another organization.
+// If the target has an archive instance, the archived snapshot of the entity is returned as the result.
+// Tables without the organization column must set Target.OrganizationColumn to "-".
+func Restore(
+	c context.Context,
+	instance db.Instance,
+	builder qb.QueryBuilder,
+	orgClient orgctx.Client,
+	errBuilder func() error,
+	target Target,
+	entityID int64,
+	opts ...Option,
+) (res_ any, found_ bool, err_ error) {
+	o := newOption(builder, opts)
+	query := builder.Update(target.Table).
+		Set(o.setColumn(), nil).
+		AndWhere(builder.Eq(map[string]any{target.IDColumn: entityID})).
+		AndWhere(builder.NotEq(map[string]any{o.setColumn(): nil}))
+	if column := target.organizationColumn(); column != "" {
+		organizationID := orgClient.IDFromContext(c)
+		if organizationID == 0 {
+			return nil, false, ebimpl.Make[ErrOrganizationRequired]().
+				SetKey(KeyOrganizationRequired).
+				SetCode(httpserver.StatusForbidden).
+				SetMessagef("organization required")
+		}
+		query = query.AndWhere(builder.Eq(map[string]any{column: organizationID}))
+	}
+	result, err := instance.Exec(c, query)
+	if err != nil {
+		return nil, false, err
+	}
+	if found_ = result.RowsAffected() > 0; !found_ || target.Archive == nil {
+		return nil, found_, nil
+	}
+	res_, _, err_ = target.Archive.GetOne(c, errBuilder, entityID)
+	return res_, found_, err_
+}
+
+// WithColumn sets the name of the column holding the deletion time (DefaultColumn by default).
+// Use a qualified name (e.g. "a.deleted_at") if queries of the entity use table aliases;
+// the qualifier is used in conditions only and is stripped from SET clauses.
+func WithColumn(v string) Option {
+	return func(o option) { o.SetColumn(v) }
+}
+
+// WithValue sets the value written into the deletion column (CURRENT_TIMESTAMP by default).
+func WithValue(v any) Option {
+	return func(o option) { o.SetValue(v) }
+}
+
+type (
+	// Client is the subset of an entity hook client required for soft deletion.
+	Client interface {
+		// SQLSelectHook returns a mutator registry for SELECT SQL queries associated with the given plugin ID.
+		SQLSelectHook(pluginID string) hook.Mutator[qb.SelectQuery]
+
+		// SQLDeleteHook returns a mutator registry for DELETE SQL queries associated with the given plugin ID.
+		SQLDeleteHook(pluginID string) hook.Mutator[qb.DeleteQuery]
+	}
+
+	// Target describes the storage of a soft-deletable entity.
+	Target struct {
+		Table              string           // entity table name
+		IDColumn           string           // entity ID column name
+		OrganizationColumn string           // organization ID column name (DefaultOrganizationColumn if empty, "-" if none)
+		Archive            archive.Instance // optional archive holding snapshots of deleted entities
+	}
+
+	// ErrOrganizationRequired is the base error of a restore run without an organization in the context.
+	ErrOrganizationRequired struct{}
+
+	// Registration is a handle to the hooks added by Register.
+	Registration struct {
+		sel hook.Registration[hook.MutatorFunc[qb.SelectQuery], qb.SelectQuery]
+		del hook.Registration[hook.MutatorFunc[qb.DeleteQuery], qb.DeleteQuery]
+	}
+
+	// Option defines a functional option for soft deletion.
+	Option func(option)
+
+	// option is an internal interface used to apply configuration.
+	option interface {
+		// SetColumn sets the name of the deletion column.
+		SetColumn(string)
+
+		// SetValue sets the value written into the deletion column.
+		SetValue(any)
+	}
+
+	// options holds soft deletion settings.
+	options struct {
+		column string
+		value  any
+	}
+)
+
+func (ErrOrganizationRequired) Error() string { return "organization required" }
+
+// Remove unregisters the soft deletion hooks.
+// Returns false if the hooks have already been removed.
+func (a Registration) Remove() bool {
+	sel := a.sel.Remove()
+	del := a.del.Remove()
+	return sel || del
+}
+
+// newOption applies the options over the defaults.
+func newOption(builder qb.QueryBuilder, opts []Option) *options {
+	o := &options{
+		column: DefaultColumn,
+		value:  builder.Sql("CURRENT_TIMESTAMP"),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// setColumn returns the deletion column without the table qualifier, as required by SET clauses.
+func (a *options) setColumn() string {
+	if i := strings.LastIndexByte(a.column, '.'); i >= 0 {
+		return a.column[i+1:]
+	}
+	return a.column
+}
+
+// organizationColumn returns the organization column, or an empty string if the table has none.
+func (a Target) organizationColumn() string {
+	switch a.OrganizationColumn {
+	case "":
+		return DefaultOrganizationColumn
+	case "-":
+		return ""
+	}
+	return a.OrganizationColumn
+}
+
+func (a *options) SetColumn(v string) { a.column = v }
+func (a *options) SetValue(v any)     { a.value = v }
@@ -0,0 +1,25 @@
+module github.com/hypershadow-io/contract/softdelete
+
+go 1.24.0
+
+require (
+	github.com/hypershadow-io/contract/archive v1.0.0
+	github.com/hypershadow-io/contract/db v1.2.0
+	github.com/hypershadow-io/contract/eb/impl v1.1.0
+	github.com/hypershadow-io/contract/hook v1.1.0
+	github.com/hypershadow-io/contract/httpserver v1.0.2
+	github.com/hypershadow-io/contract/organization/ctx v1.0.0
+	github.com/hypershadow-io/contract/qb v1.3.0
+)
+
+require (
+	github.com/hypershadow-io/contract/codec v1.0.0 // indirect
+	github.com/hypershadow-io/contract/eb v1.3.0 // indirect
+	github.com/hypershadow-io/contract/entity v1.0.0 // indirect
+	github.com/hypershadow-io/contract/fielderror v1.1.0 // indirect
+	github.com/hypershadow-io/contract/fmt v1.0.0 // indirect
+	github.com/hypershadow-io/contract/json v1.1.0 // indirect
+	github.com/hypershadow-io/contract/meta v1.0.0 // indirect
+	github.com/hypershadow-io/contract/meta/slog v1.0.0 // indirect
+	github.com/hypershadow-io/contract/utiliter v1.0.0 // indirect
+)